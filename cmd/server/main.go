@@ -42,6 +42,11 @@ func main() {
 		Characters: repositories.NewCharacterRepo(dbConn),
 		Items:      repositories.NewItemRepo(dbConn),
 		Quests:     repositories.NewQuestRepo(dbConn),
+		Drops:      repositories.NewDropRepo(dbConn),
+		Skills:     repositories.NewSkillRepo(dbConn),
+		Buddies:    repositories.NewBuddyRepo(dbConn),
+		Guilds:     repositories.NewGuildRepo(dbConn),
+		Tx:         repositories.NewTransactor(dbConn),
 	}
 
 	// Initialize WZ data providers
@@ -62,11 +67,17 @@ func main() {
 
 	npcProvider := providers.NewNPCProvider(wzProvider)
 
+	mobProvider := providers.NewMobProvider(wzProvider)
+
+	skillProvider := providers.NewSkillProvider(wzProvider)
+
 	provs := server.Providers{
 		Items:  itemProvider,
 		Maps:   mapProvider,
 		Quests: questProvider,
 		NPCs:   npcProvider,
+		Mobs:   mobProvider,
+		Skills: skillProvider,
 	}
 
 	// Create unified server
@@ -4,6 +4,7 @@ go 1.24.11
 
 require (
 	github.com/joho/godotenv v1.5.1
+	github.com/yuin/gopher-lua v1.1.1
 	golang.org/x/crypto v0.46.0
 	golang.org/x/sync v0.19.0
 	gorm.io/driver/postgres v1.6.0
@@ -17,6 +18,5 @@ require (
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
-	github.com/yuin/gopher-lua v1.1.1 // indirect
 	golang.org/x/text v0.32.0 // indirect
 )
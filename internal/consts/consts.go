@@ -3,7 +3,10 @@ package consts
 import "time"
 
 const (
-	GameVersion       uint16 = 95
-	SendVersion              = 0xFFFF - GameVersion
-	FieldTickInterval        = 100 * time.Millisecond
+	GameVersion        uint16 = 95
+	SendVersion               = 0xFFFF - GameVersion
+	FieldTickInterval         = 100 * time.Millisecond
+	MobRespawnInterval        = 7 * time.Second  // Regen delay for spawn points without a mobTime
+	DropOwnershipTime         = 15 * time.Second // How long only the owner may pick up a drop
+	DropExpireTime            = 3 * time.Minute  // How long a drop stays on the field
 )
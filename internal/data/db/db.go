@@ -22,11 +22,15 @@ func Connect(databaseUrl string) (*gorm.DB, error) {
 		&models.CharacterItem{},
 		&models.Skill{},
 		&models.SkillMacro{},
+		&models.SkillCooldown{},
 		&models.QuestRecord{},
 		&models.QuestRecordEx{},
 		&models.KeyBinding{},
 		&models.QuickSlot{},
+		&models.Buddy{},
+		&models.Guild{},
 		&models.Item{},
+		&models.DropEntry{},
 	); err != nil {
 		return nil, err
 	}
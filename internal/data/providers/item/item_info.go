@@ -51,6 +51,13 @@ func (i *ItemInfo) GetInfoOr(key ItemInfosKey, defaultValue int32) int32 {
 	return defaultValue
 }
 
+// GetInfoFlag returns whether a flag info key such as tradeBlock is set.
+// Accepts both bool and int encoded flags.
+func (i *ItemInfo) GetInfoFlag(key ItemInfosKey) bool {
+	v, ok := i.itemInfos.Get(key)
+	return ok && (v.Bool || v.Int != 0)
+}
+
 // GetSpec returns the int value for a spec key, or an error if missing/wrong type.
 func (i *ItemInfo) GetSpec(key ItemSpecsKey) (int32, error) {
 	return i.itemSpecs.GetInt(key)
@@ -64,6 +71,13 @@ func (i *ItemInfo) GetSpecOr(key ItemSpecsKey, defaultValue int32) int32 {
 	return defaultValue
 }
 
+// GetSpecFlag returns whether a flag spec key such as thaw is set.
+// Accepts both bool and int encoded flags.
+func (i *ItemInfo) GetSpecFlag(key ItemSpecsKey) bool {
+	v, ok := i.itemSpecs.Get(key)
+	return ok && (v.Bool || v.Int != 0)
+}
+
 // HasInfo returns whether the info key exists.
 func (i *ItemInfo) HasInfo(key ItemInfosKey) bool {
 	return i.itemInfos.Has(key)
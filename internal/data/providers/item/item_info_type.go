@@ -90,6 +90,7 @@ const (
 	KeyIncMP       ItemInfosKey = "incMP"
 	KeyIncMHP      ItemInfosKey = "incMHP"
 	KeyIncMHPr     ItemInfosKey = "incMHPr"
+	KeyIncMMP      ItemInfosKey = "incMMP"
 	KeyIncMaxHP    ItemInfosKey = "incMaxHP"
 	KeyIncMaxMP    ItemInfosKey = "incMaxMP"
 	KeyIncPAD      ItemInfosKey = "incPAD"
@@ -131,6 +132,7 @@ const (
 	KeyReqLevel           ItemInfosKey = "reqLevel"
 	KeyReqSkillLevel      ItemInfosKey = "reqSkillLevel"
 	KeyReqQuestOnProgress ItemInfosKey = "reqQuestOnProgress"
+	KeyReqEquipLevelMax   ItemInfosKey = "reqEquipLevelMax"
 
 	// Skill/Mastery
 	KeyMasterLevel ItemInfosKey = "masterLevel"
@@ -226,6 +228,7 @@ var itemInfoSchema = map[ItemInfosKey]ItemValueKind{
 	KeyIncMP:       ValueInt,
 	KeyIncMHP:      ValueInt,
 	KeyIncMHPr:     ValueInt,
+	KeyIncMMP:      ValueInt,
 	KeyIncMaxHP:    ValueInt,
 	KeyIncMaxMP:    ValueInt,
 	KeyIncPAD:      ValueInt,
@@ -246,7 +249,7 @@ var itemInfoSchema = map[ItemInfosKey]ItemValueKind{
 	KeyIncPeriod:   ValueInt,
 
 	// Scroll outcomes
-	KeySuccess:  ValueDir,
+	KeySuccess:  ValueInt,
 	KeyCursed:   ValueInt,
 	KeyRecover:  ValueInt,
 	KeyRandStat: ValueInt,
@@ -267,6 +270,7 @@ var itemInfoSchema = map[ItemInfosKey]ItemValueKind{
 	KeyReqLevel:           ValueInt,
 	KeyReqSkillLevel:      ValueInt,
 	KeyReqQuestOnProgress: ValueInt,
+	KeyReqEquipLevelMax:   ValueInt,
 
 	// Skill/Mastery
 	KeyMasterLevel: ValueInt,
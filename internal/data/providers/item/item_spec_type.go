@@ -41,6 +41,13 @@ const (
 	KeySpecNuffSkill   ItemSpecsKey = "nuffSkill"
 	KeySpecCooltime    ItemSpecsKey = "cooltime"
 
+	// Debuff cures
+	KeySpecPoison   ItemSpecsKey = "poison"
+	KeySpecSeal     ItemSpecsKey = "seal"
+	KeySpecDarkness ItemSpecsKey = "darkness"
+	KeySpecWeakness ItemSpecsKey = "weakness"
+	KeySpecCurse    ItemSpecsKey = "curse"
+
 	// Experience/Meso
 	KeySpecExp         ItemSpecsKey = "exp"
 	KeySpecExpR        ItemSpecsKey = "expR"
@@ -104,6 +111,13 @@ var itemSpecSchema = map[ItemSpecsKey]ItemValueKind{
 	KeySpecNuffSkill: ValueInt,
 	KeySpecCooltime:  ValueInt,
 
+	// Debuff cures
+	KeySpecPoison:   ValueBool,
+	KeySpecSeal:     ValueBool,
+	KeySpecDarkness: ValueBool,
+	KeySpecWeakness: ValueBool,
+	KeySpecCurse:    ValueBool,
+
 	// Experience/Meso
 	KeySpecExp:   ValueInt,
 	KeySpecExpR:  ValueInt,
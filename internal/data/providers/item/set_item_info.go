@@ -0,0 +1,68 @@
+package item
+
+import (
+	"strconv"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers/wz"
+)
+
+// SetItemInfo is an equipment set from Etc.wz/SetItemInfo.img
+type SetItemInfo struct {
+	setItemID int32
+	itemIDs   []int32
+	effects   map[int32]map[string]int32 // equipped part count -> stat props
+}
+
+func NewSetItemInfo(setItemID int32, setDir *wz.ImgDir) *SetItemInfo {
+	info := &SetItemInfo{
+		setItemID: setItemID,
+		effects:   make(map[int32]map[string]int32),
+	}
+
+	if setDir == nil {
+		return info
+	}
+
+	if itemDir := setDir.Get("ItemID"); itemDir != nil {
+		for _, intNode := range itemDir.Ints {
+			info.itemIDs = append(info.itemIDs, intNode.Value)
+		}
+	}
+
+	if effectDir := setDir.Get("Effect"); effectDir != nil {
+		for i := range effectDir.ImgDirs {
+			countDir := &effectDir.ImgDirs[i]
+			count, err := strconv.ParseInt(countDir.Name, 10, 32)
+			if err != nil {
+				continue
+			}
+
+			props := make(map[string]int32)
+			for _, intNode := range countDir.Ints {
+				props[intNode.Name] = intNode.Value
+			}
+			info.effects[int32(count)] = props
+		}
+	}
+
+	return info
+}
+
+func (s *SetItemInfo) GetSetItemID() int32 {
+	return s.setItemID
+}
+
+func (s *SetItemInfo) GetItemIDs() []int32 {
+	return s.itemIDs
+}
+
+// GetEffects returns the stat props of every effect unlocked by wearing count parts of the set
+func (s *SetItemInfo) GetEffects(count int32) []map[string]int32 {
+	var effects []map[string]int32
+	for n, props := range s.effects {
+		if n <= count {
+			effects = append(effects, props)
+		}
+	}
+	return effects
+}
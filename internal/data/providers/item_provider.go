@@ -29,6 +29,7 @@ type ItemProvider struct {
 	petActions       map[int32]map[int32]*item.PetInteraction
 	specialItemNames map[int32]string
 	itemOptionInfos  map[int32]*item.ItemOptionInfo
+	setItemInfos     map[int32]*item.SetItemInfo
 }
 
 func NewItemProvider(wzProvider *wz.WzProvider) (*ItemProvider, error) {
@@ -40,6 +41,7 @@ func NewItemProvider(wzProvider *wz.WzProvider) (*ItemProvider, error) {
 		petActions:       make(map[int32]map[int32]*item.PetInteraction),
 		specialItemNames: make(map[int32]string),
 		itemOptionInfos:  make(map[int32]*item.ItemOptionInfo),
+		setItemInfos:     make(map[int32]*item.SetItemInfo),
 	}
 
 	if err := p.loadItemInfos(); err != nil {
@@ -58,6 +60,10 @@ func NewItemProvider(wzProvider *wz.WzProvider) (*ItemProvider, error) {
 		return nil, err
 	}
 
+	if err := p.loadSetItemInfos(); err != nil {
+		return nil, err
+	}
+
 	return p, nil
 }
 
@@ -265,6 +271,31 @@ func (p *ItemProvider) loadItemOptionInfos() error {
 	return nil
 }
 
+func (p *ItemProvider) loadSetItemInfos() error {
+	// Equipment sets are in Etc.wz/SetItemInfo.img
+	setImg, err := p.wz.Dir("Etc.wz").Image("SetItemInfo")
+	if err != nil {
+		return fmt.Errorf("could not resolve Etc.wz/SetItemInfo.img: %w", err)
+	}
+
+	root := setImg.Root()
+	if root == nil {
+		return nil
+	}
+
+	for i := range root.ImgDirs {
+		setEntry := &root.ImgDirs[i]
+		setItemID, err := strconv.ParseInt(setEntry.Name, 10, 32)
+		if err != nil {
+			continue
+		}
+
+		p.setItemInfos[int32(setItemID)] = item.NewSetItemInfo(int32(setItemID), setEntry)
+	}
+
+	return nil
+}
+
 // GetItemInfo returns the item info for the given item ID.
 func (p *ItemProvider) GetItemInfo(itemID int32) *item.ItemInfo {
 	p.mu.RLock()
@@ -331,3 +362,10 @@ func (p *ItemProvider) GetMatchingItemOptions(grade item.ItemGrade, reqLevel int
 	}
 	return result
 }
+
+// GetSetItemInfo returns the equipment set info for the given set ID.
+func (p *ItemProvider) GetSetItemInfo(setItemID int32) *item.SetItemInfo {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.setItemInfos[setItemID]
+}
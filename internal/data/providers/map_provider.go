@@ -11,6 +11,7 @@ type MapData struct {
 	ID           int32
 	ReturnMap    int32
 	ForcedReturn int32
+	MobRate      float64 // Mob population multiplier (info/mobRate)
 	SpawnPoint   Portal
 	Portals      map[string]Portal
 	Footholds    []Foothold
@@ -38,7 +39,7 @@ type LifeSpawn struct {
 	Rx1     uint16 // Right roaming bound
 	F       bool   // Flipped (facing left)
 	Hide    bool   // Hidden on spawn
-	MobTime int32  // Respawn time for mobs (milliseconds)
+	MobTime int32  // Respawn time for mobs in seconds (0 = regular regen, <0 = never)
 	Team    int32  // Team number (for PvP maps)
 }
 
@@ -149,6 +150,11 @@ func (p *MapProvider) parseMapData(mapID int32, root *wz.ImgDir) (*MapData, erro
 		mapData.ForcedReturn = forcedReturn
 	}
 
+	mapData.MobRate = 1.0
+	if mobRate, err := info.GetFloat("mobRate"); err == nil && mobRate > 0 {
+		mapData.MobRate = mobRate
+	}
+
 	// Parse portals
 	if portalSection := root.Get("portal"); portalSection != nil {
 		for i := range portalSection.ImgDirs {
@@ -0,0 +1,234 @@
+package providers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers/wz"
+)
+
+// Element is a damage element used by mob elemental attributes
+type Element byte
+
+const (
+	ElementPhysical  Element = 'P'
+	ElementFire      Element = 'F'
+	ElementIce       Element = 'I'
+	ElementLightning Element = 'L'
+	ElementPoison    Element = 'S'
+	ElementHoly      Element = 'H'
+	ElementDark      Element = 'D'
+)
+
+// ElementAttr is how a mob reacts to an element
+type ElementAttr byte
+
+const (
+	ElementAttrNormal ElementAttr = 0
+	ElementAttrImmune ElementAttr = 1
+	ElementAttrStrong ElementAttr = 2
+	ElementAttrWeak   ElementAttr = 3
+)
+
+// MobSkill is a skill entry from a mob's info/skill list
+type MobSkill struct {
+	SkillID int32
+	Level   int32
+}
+
+// MobTemplate contains mob data loaded from Mob.wz and String.wz
+type MobTemplate struct {
+	ID          int32
+	Name        string
+	Level       int32
+	MaxHP       int32
+	MaxMP       int32
+	EXP         int32
+	PAD         int32 // Physical attack
+	PDD         int32 // Physical defence
+	MAD         int32 // Magic attack
+	MDD         int32 // Magic defence
+	ACC         int32
+	EVA         int32
+	Speed       int32
+	Boss        bool
+	Undead      bool
+	FirstAttack bool // Attacks players on sight
+	BodyAttack  bool // Damages players on touch
+	Elements    map[Element]ElementAttr
+	Skills      []MobSkill
+	Revives     []int32 // Mobs spawned when this one dies
+}
+
+// ElementAttr returns the mob's attribute for an element
+func (t *MobTemplate) ElementAttr(e Element) ElementAttr {
+	return t.Elements[e]
+}
+
+// MobProvider loads and caches mob templates from Mob.wz
+type MobProvider struct {
+	wz *wz.WzProvider
+
+	mu        sync.RWMutex
+	templates map[int32]*MobTemplate
+	names     map[int32]string
+}
+
+// NewMobProvider creates a new mob provider. Mob names are loaded up front,
+// templates are parsed from Mob.wz the first time each mob is requested.
+func NewMobProvider(wzProvider *wz.WzProvider) *MobProvider {
+	p := &MobProvider{
+		wz:        wzProvider,
+		templates: make(map[int32]*MobTemplate),
+		names:     make(map[int32]string),
+	}
+	p.loadMobNames()
+	return p
+}
+
+// GetMobTemplate returns the template for a mob ID, or nil if it can't be loaded
+func (p *MobProvider) GetMobTemplate(mobID int32) *MobTemplate {
+	p.mu.RLock()
+	t, ok := p.templates[mobID]
+	p.mu.RUnlock()
+	if ok {
+		return t
+	}
+
+	t, err := p.loadMobTemplate(mobID)
+	if err != nil {
+		log.Printf("[MobProvider] %v", err)
+	}
+
+	// Cache misses too so a broken mob isn't re-parsed on every spawn
+	p.mu.Lock()
+	p.templates[mobID] = t
+	p.mu.Unlock()
+
+	return t
+}
+
+// GetMobName returns the name of a mob by template ID
+func (p *MobProvider) GetMobName(mobID int32) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.names[mobID]
+}
+
+func (p *MobProvider) loadMobTemplate(mobID int32) (*MobTemplate, error) {
+	info, err := p.mobInfo(mobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Linked mobs reuse another mob's data (e.g. recoloured or event copies)
+	if link, err := info.GetInt("link"); err == nil && link != 0 {
+		if linked, err := p.mobInfo(link); err == nil {
+			info = linked
+		}
+	}
+
+	t := &MobTemplate{
+		ID:          mobID,
+		Name:        p.GetMobName(mobID),
+		Level:       wzGetInt(info, "level"),
+		MaxHP:       wzGetInt(info, "maxHP"),
+		MaxMP:       wzGetInt(info, "maxMP"),
+		EXP:         wzGetInt(info, "exp"),
+		PAD:         wzGetInt(info, "PADamage"),
+		PDD:         wzGetInt(info, "PDDamage"),
+		MAD:         wzGetInt(info, "MADamage"),
+		MDD:         wzGetInt(info, "MDDamage"),
+		ACC:         wzGetInt(info, "acc"),
+		EVA:         wzGetInt(info, "eva"),
+		Speed:       wzGetInt(info, "speed"),
+		Boss:        wzGetInt(info, "boss") != 0,
+		Undead:      wzGetInt(info, "undead") != 0,
+		FirstAttack: wzGetInt(info, "firstAttack") != 0,
+		BodyAttack:  wzGetInt(info, "bodyAttack") != 0,
+		Elements:    parseElemAttr(wzGetString(info, "elemAttr")),
+	}
+
+	if skills := info.Get("skill"); skills != nil {
+		for i := range skills.ImgDirs {
+			entry := &skills.ImgDirs[i]
+			t.Skills = append(t.Skills, MobSkill{
+				SkillID: wzGetInt(entry, "skill"),
+				Level:   wzGetInt(entry, "level"),
+			})
+		}
+	}
+
+	if revives := info.Get("revive"); revives != nil {
+		for _, n := range revives.Ints {
+			t.Revives = append(t.Revives, n.Value)
+		}
+	}
+
+	return t, nil
+}
+
+// mobInfo returns the info section of Mob.wz/<id>.img
+func (p *MobProvider) mobInfo(mobID int32) (*wz.ImgDir, error) {
+	img, err := p.wz.Dir("Mob.wz").Image(fmt.Sprintf("%07d", mobID))
+	if err != nil {
+		return nil, fmt.Errorf("could not load mob %d: %w", mobID, err)
+	}
+
+	root := img.Root()
+	if root == nil {
+		return nil, fmt.Errorf("mob %d has no root", mobID)
+	}
+
+	info := root.Get("info")
+	if info == nil {
+		return nil, fmt.Errorf("mob %d missing info section", mobID)
+	}
+	return info, nil
+}
+
+// parseElemAttr parses an elemAttr string such as "F2I3" (fire strong, ice weak)
+func parseElemAttr(s string) map[Element]ElementAttr {
+	attrs := make(map[Element]ElementAttr)
+	for i := 0; i+1 < len(s); i += 2 {
+		attr, err := strconv.Atoi(s[i+1 : i+2])
+		if err != nil {
+			continue
+		}
+		attrs[Element(s[i])] = ElementAttr(attr)
+	}
+	return attrs
+}
+
+// loadMobNames loads mob names from String.wz/Mob.img
+func (p *MobProvider) loadMobNames() {
+	img, err := p.wz.Dir("String.wz").Image("Mob")
+	if err != nil {
+		log.Printf("[MobProvider] Failed to load String.wz/Mob.img: %v", err)
+		return
+	}
+
+	root := img.Root()
+	if root == nil {
+		log.Printf("[MobProvider] Mob.img has no root")
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i := range root.ImgDirs {
+		mobDir := &root.ImgDirs[i]
+		mobID, err := strconv.ParseInt(mobDir.Name, 10, 32)
+		if err != nil {
+			continue
+		}
+		if name, err := mobDir.GetString("name"); err == nil {
+			p.names[int32(mobID)] = name
+		}
+	}
+
+	log.Printf("[MobProvider] Loaded %d mob names", len(p.names))
+}
@@ -1,6 +1,7 @@
 package providers
 
 import (
+	"fmt"
 	"log"
 	"strconv"
 	"sync"
@@ -21,13 +22,17 @@ type NPCProvider struct {
 	npcs  map[int32]*NPCInfo
 	mu    sync.RWMutex
 	loaded bool
+
+	scripts  map[int32]string // npcID -> script name from Npc.wz, loaded on demand
+	scriptMu sync.RWMutex
 }
 
 // NewNPCProvider creates a new NPC provider
 func NewNPCProvider(wzProvider *wz.WzProvider) *NPCProvider {
 	p := &NPCProvider{
 		wz:   wzProvider,
-		npcs: make(map[int32]*NPCInfo),
+		npcs:    make(map[int32]*NPCInfo),
+		scripts: make(map[int32]string),
 	}
 	p.loadNPCStrings()
 	return p
@@ -63,6 +68,33 @@ func (p *NPCProvider) GetNPCInfo(npcID int32) *NPCInfo {
 	return p.npcs[npcID]
 }
 
+// GetNPCScript returns the script name from Npc.wz/<id>.img/info/script, or "" if the NPC has none
+func (p *NPCProvider) GetNPCScript(npcID int32) string {
+	p.scriptMu.RLock()
+	name, ok := p.scripts[npcID]
+	p.scriptMu.RUnlock()
+	if ok {
+		return name
+	}
+
+	img, err := p.wz.Dir("Npc.wz").Image(fmt.Sprintf("%07d", npcID))
+	if err == nil && img.Root() != nil {
+		if info := img.Root().Get("info"); info != nil {
+			if scripts := info.Get("script"); scripts != nil {
+				if first := scripts.Get("0"); first != nil {
+					name, _ = first.GetString("script")
+				}
+			}
+		}
+	}
+
+	p.scriptMu.Lock()
+	p.scripts[npcID] = name
+	p.scriptMu.Unlock()
+
+	return name
+}
+
 // loadNPCStrings loads NPC names from String.wz/Npc.img
 func (p *NPCProvider) loadNPCStrings() {
 	p.mu.Lock()
@@ -275,6 +275,9 @@ func (p *QuestProvider) parseQuestAct(questID int32, state int32, dir *wz.ImgDir
 			if actItem.Count == 0 {
 				actItem.Count = 1
 			}
+			if _, err := itemEntry.GetInt("gender"); err != nil {
+				actItem.Gender = -1 // any
+			}
 			if actItem.Prop == 0 {
 				actItem.Prop = 100 // 100% by default
 			}
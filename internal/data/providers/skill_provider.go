@@ -0,0 +1,215 @@
+package providers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers/wz"
+)
+
+// SkillLevel holds the effect values of one skill level
+type SkillLevel struct {
+	MPCon       int32
+	HPCon       int32
+	ItemCon     int32 // Item consumed on use (e.g. summoning rocks)
+	ItemConNo   int32
+	BulletCount int32
+	Time        int32 // Buff duration in seconds
+	Cooltime    int32 // Cooldown in seconds
+	Damage      int32 // Damage percentage
+	AttackCount int32
+	MobCount    int32
+	Range       int32
+	Prop        int32 // Success chance in percent
+	Mastery     int32
+	X           int32
+	Y           int32
+	Z           int32
+	PAD         int32
+	PDD         int32
+	MAD         int32
+	MDD         int32
+	ACC         int32
+	EVA         int32
+	Speed       int32
+	Jump        int32
+	HP          int32
+	MP          int32
+
+	// Area of effect corners relative to the caster facing left, zero if the skill has none
+	LTX, LTY int32
+	RBX, RBY int32
+}
+
+// HasArea returns true if the skill level affects an area around the caster
+func (l *SkillLevel) HasArea() bool {
+	return l.LTX != l.RBX && l.LTY != l.RBY
+}
+
+// SkillTemplate contains skill data loaded from Skill.wz
+type SkillTemplate struct {
+	ID          int32
+	MasterLevel int32 // Initial master level for skills that need one (0 otherwise)
+	Invisible   bool  // Hidden from the skill window until learned
+	Reqs        map[int32]int32
+	Levels      []SkillLevel // Index 0 is level 1
+}
+
+// MaxLevel returns the highest level the skill can reach
+func (t *SkillTemplate) MaxLevel() int32 {
+	return int32(len(t.Levels))
+}
+
+// Level returns the effect values for a skill level, or nil if out of range
+func (t *SkillTemplate) Level(level int32) *SkillLevel {
+	if level < 1 || int(level) > len(t.Levels) {
+		return nil
+	}
+	return &t.Levels[level-1]
+}
+
+// SkillProvider loads and caches skill templates from Skill.wz
+type SkillProvider struct {
+	wz *wz.WzProvider
+
+	mu     sync.RWMutex
+	skills map[int32]*SkillTemplate
+	jobs   map[int32]bool // Job images already parsed
+}
+
+// NewSkillProvider creates a new skill provider. Skills are parsed per job
+// image the first time a skill of that job is requested.
+func NewSkillProvider(wzProvider *wz.WzProvider) *SkillProvider {
+	return &SkillProvider{
+		wz:     wzProvider,
+		skills: make(map[int32]*SkillTemplate),
+		jobs:   make(map[int32]bool),
+	}
+}
+
+// GetSkill returns the template for a skill ID, or nil if it doesn't exist
+func (p *SkillProvider) GetSkill(skillID int32) *SkillTemplate {
+	job := skillID / 10000
+
+	p.mu.RLock()
+	t, loaded := p.skills[skillID], p.jobs[job]
+	p.mu.RUnlock()
+	if loaded {
+		return t
+	}
+
+	skills, err := p.loadJobSkills(job)
+	if err != nil {
+		log.Printf("[SkillProvider] %v", err)
+	}
+
+	// Mark the job loaded even on failure so a missing image isn't re-read on every lookup
+	p.mu.Lock()
+	for id, s := range skills {
+		p.skills[id] = s
+	}
+	p.jobs[job] = true
+	t = p.skills[skillID]
+	p.mu.Unlock()
+
+	return t
+}
+
+// loadJobSkills parses every skill in Skill.wz/<job>.img
+func (p *SkillProvider) loadJobSkills(job int32) (map[int32]*SkillTemplate, error) {
+	img, err := p.wz.Dir("Skill.wz").Image(fmt.Sprintf("%03d", job))
+	if err != nil {
+		return nil, fmt.Errorf("could not load skills for job %d: %w", job, err)
+	}
+
+	root := img.Root()
+	if root == nil {
+		return nil, fmt.Errorf("skill image for job %d has no root", job)
+	}
+
+	skillDir := root.Get("skill")
+	if skillDir == nil {
+		return nil, nil
+	}
+
+	skills := make(map[int32]*SkillTemplate, len(skillDir.ImgDirs))
+	for i := range skillDir.ImgDirs {
+		dir := &skillDir.ImgDirs[i]
+		id, err := strconv.ParseInt(dir.Name, 10, 32)
+		if err != nil {
+			continue
+		}
+		skills[int32(id)] = parseSkill(int32(id), dir)
+	}
+	return skills, nil
+}
+
+func parseSkill(skillID int32, dir *wz.ImgDir) *SkillTemplate {
+	t := &SkillTemplate{
+		ID:          skillID,
+		MasterLevel: wzGetInt(dir, "masterLevel"),
+		Invisible:   wzGetInt(dir, "invisible") != 0,
+		Reqs:        make(map[int32]int32),
+	}
+
+	if req := dir.Get("req"); req != nil {
+		for _, n := range req.Ints {
+			if id, err := strconv.ParseInt(n.Name, 10, 32); err == nil {
+				t.Reqs[int32(id)] = n.Value
+			}
+		}
+	}
+
+	levels := dir.Get("level")
+	if levels == nil {
+		return t
+	}
+
+	// Level entries are named "1".."n" but aren't guaranteed to be in order
+	byLevel := make(map[int]*wz.ImgDir, len(levels.ImgDirs))
+	for i := range levels.ImgDirs {
+		if n, err := strconv.Atoi(levels.ImgDirs[i].Name); err == nil && n > 0 {
+			byLevel[n] = &levels.ImgDirs[i]
+		}
+	}
+	for n := 1; byLevel[n] != nil; n++ {
+		t.Levels = append(t.Levels, parseSkillLevel(byLevel[n]))
+	}
+	return t
+}
+
+func parseSkillLevel(dir *wz.ImgDir) SkillLevel {
+	lv := SkillLevel{
+		MPCon:       wzGetInt(dir, "mpCon"),
+		HPCon:       wzGetInt(dir, "hpCon"),
+		ItemCon:     wzGetInt(dir, "itemCon"),
+		ItemConNo:   wzGetInt(dir, "itemConNo"),
+		BulletCount: wzGetInt(dir, "bulletCount"),
+		Time:        wzGetInt(dir, "time"),
+		Cooltime:    wzGetInt(dir, "cooltime"),
+		Damage:      wzGetInt(dir, "damage"),
+		AttackCount: wzGetInt(dir, "attackCount"),
+		MobCount:    wzGetInt(dir, "mobCount"),
+		Range:       wzGetInt(dir, "range"),
+		Prop:        wzGetInt(dir, "prop"),
+		Mastery:     wzGetInt(dir, "mastery"),
+		X:           wzGetInt(dir, "x"),
+		Y:           wzGetInt(dir, "y"),
+		Z:           wzGetInt(dir, "z"),
+		PAD:         wzGetInt(dir, "pad"),
+		PDD:         wzGetInt(dir, "pdd"),
+		MAD:         wzGetInt(dir, "mad"),
+		MDD:         wzGetInt(dir, "mdd"),
+		ACC:         wzGetInt(dir, "acc"),
+		EVA:         wzGetInt(dir, "eva"),
+		Speed:       wzGetInt(dir, "speed"),
+		Jump:        wzGetInt(dir, "jump"),
+		HP:          wzGetInt(dir, "hp"),
+		MP:          wzGetInt(dir, "mp"),
+	}
+	lv.LTX, lv.LTY, _ = dir.GetVector("lt")
+	lv.RBX, lv.RBY, _ = dir.GetVector("rb")
+	return lv
+}
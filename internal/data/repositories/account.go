@@ -64,3 +64,31 @@ func (r *accountRepo) FindByID(ctx context.Context, id uint) (*models.Account, e
 	}
 	return &account, nil
 }
+
+// SetPIC hashes and stores the account's secondary password, clearing any failed attempts
+func (r *accountRepo) SetPIC(ctx context.Context, account *models.Account, pic string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(pic), bcryptCost)
+	if err != nil {
+		return err
+	}
+
+	account.PICHash = string(hash)
+	account.PICFailures = 0
+	account.PICLockedUntil = nil
+	return r.UpdatePIC(ctx, account)
+}
+
+// UpdatePIC stores only the account's PIC hash, failure count and lockout
+func (r *accountRepo) UpdatePIC(ctx context.Context, account *models.Account) error {
+	return r.db.WithContext(ctx).Model(account).
+		Select("pic_hash", "pic_failures", "pic_locked_until").
+		Updates(account).Error
+}
+
+func (r *accountRepo) VerifyPIC(account *models.Account, pic string) bool {
+	if account.PICHash == "" {
+		return false
+	}
+	err := bcrypt.CompareHashAndPassword([]byte(account.PICHash), []byte(pic))
+	return err == nil
+}
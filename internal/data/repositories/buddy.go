@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/interfaces"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type buddyRepo struct {
+	db *gorm.DB
+}
+
+func NewBuddyRepo(db *gorm.DB) interfaces.BuddyRepo {
+	return &buddyRepo{db: db}
+}
+
+func (r *buddyRepo) GetBuddies(ctx context.Context, characterID uint) ([]*models.Buddy, error) {
+	var buddies []*models.Buddy
+	err := r.db.WithContext(ctx).
+		Where("character_id = ?", characterID).
+		Order("id asc").
+		Find(&buddies).Error
+	return buddies, err
+}
+
+// GetBuddy returns one entry of a character's buddy list, or nil if there is none
+func (r *buddyRepo) GetBuddy(ctx context.Context, characterID, buddyID uint) (*models.Buddy, error) {
+	var buddy models.Buddy
+	err := r.db.WithContext(ctx).
+		Where("character_id = ? AND buddy_id = ?", characterID, buddyID).
+		First(&buddy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &buddy, nil
+}
+
+// CountListed counts the entries that take up space on a character's buddy list.
+// Requests the character hasn't answered don't count.
+func (r *buddyRepo) CountListed(ctx context.Context, characterID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Buddy{}).
+		Where("character_id = ? AND status <> ?", characterID, models.BuddyStatusReceived).
+		Count(&count).Error
+	return count, err
+}
+
+// GetFriendsOf returns the IDs of characters that have the given character as an accepted buddy
+func (r *buddyRepo) GetFriendsOf(ctx context.Context, buddyID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).
+		Model(&models.Buddy{}).
+		Where("buddy_id = ? AND status = ?", buddyID, models.BuddyStatusFriend).
+		Pluck("character_id", &ids).Error
+	return ids, err
+}
+
+// Save inserts or updates the given entries in a single transaction
+func (r *buddyRepo) Save(ctx context.Context, buddies ...*models.Buddy) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, b := range buddies {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "character_id"}, {Name: "buddy_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"buddy_name", "group_name", "status", "updated_at"}),
+			}).Create(b).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete removes the friendship from both characters' lists
+func (r *buddyRepo) Delete(ctx context.Context, characterID, buddyID uint) error {
+	return r.db.WithContext(ctx).
+		Where("(character_id = ? AND buddy_id = ?) OR (character_id = ? AND buddy_id = ?)",
+			characterID, buddyID, buddyID, characterID).
+		Delete(&models.Buddy{}).Error
+}
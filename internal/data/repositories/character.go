@@ -2,10 +2,12 @@ package repositories
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
 	"github.com/Jinw00Arise/Jinwoo/internal/interfaces"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type characterRepo struct {
@@ -70,6 +72,116 @@ func (r *characterRepo) FindByID(ctx context.Context, id uint) (*models.Characte
 	return &character, nil
 }
 
+// FindByName returns the character with the given name in a world, ignoring case
+func (r *characterRepo) FindByName(ctx context.Context, worldID byte, name string) (*models.Character, error) {
+	var character models.Character
+	if err := r.db.WithContext(ctx).
+		Where("world_id = ? AND name_index = ?", worldID, strings.ToLower(name)).
+		First(&character).Error; err != nil {
+		return nil, err
+	}
+	return &character, nil
+}
+
 func (r *characterRepo) Update(ctx context.Context, char *models.Character) error {
 	return r.db.WithContext(ctx).Save(char).Error
 }
+
+// Save persists the character, its inventory and its quest records in a single transaction.
+// Items and quest records missing from the given slices are deleted.
+func (r *characterRepo) Save(ctx context.Context, char *models.Character, items []*models.CharacterItem, quests []*models.QuestRecord) error {
+	return dbFrom(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Assign SNs to items created since the last save
+		for _, it := range items {
+			it.CharacterID = char.ID
+			if it.ItemSN == 0 {
+				char.ItemSNCounter++
+				it.ItemSN = int64(char.ItemSNCounter)
+			}
+		}
+
+		if err := tx.Save(char).Error; err != nil {
+			return err
+		}
+
+		// Rewrite the inventory so slot swaps can't trip the (character, inv, slot) unique index
+		if err := tx.Where("character_id = ?", char.ID).Delete(&models.CharacterItem{}).Error; err != nil {
+			return err
+		}
+
+		var existing, created []*models.CharacterItem
+		for _, it := range items {
+			if it.ID != 0 {
+				existing = append(existing, it)
+			} else {
+				created = append(created, it)
+			}
+		}
+		if len(existing) > 0 {
+			if err := tx.Create(&existing).Error; err != nil {
+				return err
+			}
+		}
+		if len(created) > 0 {
+			if err := tx.Create(&created).Error; err != nil {
+				return err
+			}
+		}
+
+		questIDs := make([]uint16, 0, len(quests))
+		for _, qr := range quests {
+			qr.CharacterID = char.ID
+			questIDs = append(questIDs, qr.QuestID)
+
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "character_id"}, {Name: "quest_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"state", "progress", "completed_at", "updated_at"}),
+			}).Create(qr).Error; err != nil {
+				return err
+			}
+		}
+
+		// Drop forfeited quests
+		del := tx.Where("character_id = ?", char.ID)
+		if len(questIDs) > 0 {
+			del = del.Where("quest_id NOT IN ?", questIDs)
+		}
+		return del.Delete(&models.QuestRecord{}).Error
+	})
+}
+
+// Delete removes the character along with everything stored against it in a single transaction
+func (r *characterRepo) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		owned := []any{
+			&models.CharacterItem{},
+			&models.Skill{},
+			&models.SkillMacro{},
+			&models.SkillCooldown{},
+			&models.QuestRecord{},
+			&models.QuestRecordEx{},
+			&models.KeyBinding{},
+			&models.QuickSlot{},
+			&models.Buddy{},
+		}
+		for _, model := range owned {
+			if err := tx.Where("character_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		// Drop the character from other buddy lists too
+		if err := tx.Where("buddy_id = ?", id).Delete(&models.Buddy{}).Error; err != nil {
+			return err
+		}
+
+		res := tx.Delete(&models.Character{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/interfaces"
+	"gorm.io/gorm"
+)
+
+type dropRepo struct {
+	db *gorm.DB
+}
+
+func NewDropRepo(db *gorm.DB) interfaces.DropRepo {
+	return &dropRepo{db: db}
+}
+
+func (r *dropRepo) GetAll(ctx context.Context) ([]*models.DropEntry, error) {
+	var entries []*models.DropEntry
+	err := r.db.WithContext(ctx).
+		Order("source_type asc, source_id asc, id asc").
+		Find(&entries).Error
+	return entries, err
+}
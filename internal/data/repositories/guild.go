@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/interfaces"
+	"gorm.io/gorm"
+)
+
+type guildRepo struct {
+	db *gorm.DB
+}
+
+func NewGuildRepo(db *gorm.DB) interfaces.GuildRepo {
+	return &guildRepo{db: db}
+}
+
+func (r *guildRepo) FindByID(ctx context.Context, id uint) (*models.Guild, error) {
+	var guild models.Guild
+	if err := r.db.WithContext(ctx).First(&guild, id).Error; err != nil {
+		return nil, err
+	}
+	return &guild, nil
+}
+
+func (r *guildRepo) NameExists(ctx context.Context, worldID byte, name string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Guild{}).
+		Where("world_id = ? AND name_index = ?", worldID, strings.ToLower(name)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Create inserts the guild and makes the master its first member
+func (r *guildRepo) Create(ctx context.Context, guild *models.Guild) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(guild).Error; err != nil {
+			return err
+		}
+		return setGuildMember(tx, guild.MasterID, &guild.ID, models.GuildGradeMaster)
+	})
+}
+
+func (r *guildRepo) Update(ctx context.Context, guild *models.Guild) error {
+	return r.db.WithContext(ctx).Save(guild).Error
+}
+
+// Delete removes the guild and clears it from all of its members
+func (r *guildRepo) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Character{}).
+			Where("guild_id = ?", id).
+			Updates(map[string]any{"guild_id": nil, "guild_grade": 0}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Guild{}, id).Error
+	})
+}
+
+// GetMembers returns the members of a guild, ordered by grade
+func (r *guildRepo) GetMembers(ctx context.Context, guildID uint) ([]*models.Character, error) {
+	var members []*models.Character
+	err := r.db.WithContext(ctx).
+		Select("id", "name", "job", "level", "guild_id", "guild_grade").
+		Where("guild_id = ?", guildID).
+		Order("guild_grade asc, id asc").
+		Find(&members).Error
+	return members, err
+}
+
+// SetMember moves a character into a guild at the given grade, or out of their
+// guild when guildID is nil
+func (r *guildRepo) SetMember(ctx context.Context, characterID uint, guildID *uint, grade byte) error {
+	return setGuildMember(r.db.WithContext(ctx), characterID, guildID, grade)
+}
+
+func setGuildMember(tx *gorm.DB, characterID uint, guildID *uint, grade byte) error {
+	return tx.Model(&models.Character{}).
+		Where("id = ?", characterID).
+		Updates(map[string]any{"guild_id": guildID, "guild_grade": grade}).Error
+}
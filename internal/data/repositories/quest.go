@@ -48,3 +48,9 @@ func (r *questRepo) SaveQuestRecord(ctx context.Context, characterID uint, quest
 		}).
 		Create(record).Error
 }
+
+func (r *questRepo) DeleteQuestRecord(ctx context.Context, characterID uint, questID uint16) error {
+	return r.db.WithContext(ctx).
+		Where("character_id = ? AND quest_id = ?", characterID, questID).
+		Delete(&models.QuestRecord{}).Error
+}
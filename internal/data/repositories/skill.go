@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/interfaces"
+	"gorm.io/gorm"
+)
+
+type skillRepo struct {
+	db *gorm.DB
+}
+
+func NewSkillRepo(db *gorm.DB) interfaces.SkillRepo {
+	return &skillRepo{db: db}
+}
+
+func (r *skillRepo) GetSkills(ctx context.Context, characterID uint) ([]*models.Skill, error) {
+	var skills []*models.Skill
+	err := r.db.WithContext(ctx).
+		Where("character_id = ?", characterID).
+		Order("skill_id asc").
+		Find(&skills).Error
+	return skills, err
+}
+
+func (r *skillRepo) GetMacros(ctx context.Context, characterID uint) ([]*models.SkillMacro, error) {
+	var macros []*models.SkillMacro
+	err := r.db.WithContext(ctx).
+		Where("character_id = ?", characterID).
+		Order("position asc").
+		Find(&macros).Error
+	return macros, err
+}
+
+func (r *skillRepo) GetCooldowns(ctx context.Context, characterID uint) ([]*models.SkillCooldown, error) {
+	var cooldowns []*models.SkillCooldown
+	err := r.db.WithContext(ctx).
+		Where("character_id = ? AND expires_at > ?", characterID, time.Now()).
+		Find(&cooldowns).Error
+	return cooldowns, err
+}
+
+// Save replaces a character's skill records, macros and cooldowns in a single transaction
+func (r *skillRepo) Save(ctx context.Context, characterID uint, skills []*models.Skill, macros []*models.SkillMacro, cooldowns []*models.SkillCooldown) error {
+	return dbFrom(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("character_id = ?", characterID).Delete(&models.Skill{}).Error; err != nil {
+			return err
+		}
+		if len(skills) > 0 {
+			for _, s := range skills {
+				s.CharacterID = characterID
+			}
+			if err := tx.Create(&skills).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("character_id = ?", characterID).Delete(&models.SkillMacro{}).Error; err != nil {
+			return err
+		}
+		if len(macros) > 0 {
+			for _, m := range macros {
+				m.CharacterID = characterID
+			}
+			if err := tx.Create(&macros).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("character_id = ?", characterID).Delete(&models.SkillCooldown{}).Error; err != nil {
+			return err
+		}
+		if len(cooldowns) > 0 {
+			if err := tx.Create(&cooldowns).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/interfaces"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) interfaces.Transactor {
+	return &transactor{db: db}
+}
+
+// Transaction runs fn in a database transaction. Repository calls made with the
+// context passed to fn join it.
+func (t *transactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return dbFrom(ctx, t.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// dbFrom returns the transaction carried by ctx, or db outside of one
+func dbFrom(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db
+}
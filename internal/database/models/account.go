@@ -3,9 +3,15 @@ package models
 import "time"
 
 type Account struct {
-	ID           uint   `gorm:"primaryKey"`
-	Username     string `gorm:"uniqueIndex;size:32;not null"`
-	PasswordHash string `gorm:"size:255;not null"`
-	Banned       bool   `gorm:"default:false"`
-	CreatedAt    time.Time
+	ID           uint       `gorm:"primaryKey"`
+	Username     string     `gorm:"uniqueIndex;size:32;not null"`
+	PasswordHash string     `gorm:"size:255;not null"`
+	Banned       bool       `gorm:"default:false"`
+	Birthday     *time.Time `gorm:"type:date"` // Checked on character deletion; without one, deletion needs a PIC
+
+	// Secondary password (PIC)
+	PICHash        string `gorm:"size:255"`
+	PICFailures    int    `gorm:"default:0;not null"`
+	PICLockedUntil *time.Time
+	CreatedAt      time.Time
 }
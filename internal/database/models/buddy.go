@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// BuddyStatus represents the state of a buddy list entry.
+type BuddyStatus byte
+
+const (
+	BuddyStatusFriend   BuddyStatus = 0 // Both characters accepted
+	BuddyStatusSent     BuddyStatus = 1 // Waiting for the buddy to accept
+	BuddyStatusReceived BuddyStatus = 2 // Waiting for this character to accept
+)
+
+// DefaultBuddyGroup is the group buddies are added to when none is given.
+const DefaultBuddyGroup = "Default Group"
+
+// Buddy represents an entry on a character's buddy list. Each friendship is
+// stored once per side so either character can load their list on its own.
+type Buddy struct {
+	ID          uint   `gorm:"primaryKey"`
+	CharacterID uint   `gorm:"uniqueIndex:ux_character_buddy;not null"`
+	BuddyID     uint   `gorm:"uniqueIndex:ux_character_buddy;index;not null"`
+	BuddyName   string `gorm:"size:13;not null"`
+	GroupName   string `gorm:"size:17;not null"`
+	Status      byte   `gorm:"default:0;not null"` // BuddyStatus
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -43,9 +43,10 @@ type Character struct {
 	// Cassandra scalar fields you’ll want
 	ExtSlotExpire *time.Time `gorm:""`
 	ItemSNCounter int32      `gorm:"default:0;not null"`
-	FriendMax     int32      `gorm:"default:0;not null"`
+	FriendMax     int32      `gorm:"default:20;not null"`
 	PartyID       *uint      `gorm:"index"`
 	GuildID       *uint      `gorm:"index"`
+	GuildGrade    byte       `gorm:"default:0;not null"` // 1 = master, 2 = jr. master, 3-5 = member
 	MaxLevelTime  *time.Time `gorm:""`
 
 	CreatedAt time.Time
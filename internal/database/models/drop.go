@@ -0,0 +1,27 @@
+package models
+
+// DropSourceType identifies what kind of life a drop entry belongs to
+type DropSourceType uint8
+
+const (
+	DropSourceMob     DropSourceType = 1
+	DropSourceReactor DropSourceType = 2
+)
+
+// DropChanceMax is the chance value of a guaranteed drop
+const DropChanceMax = 1000000
+
+// DropEntry is one possible drop of a mob or reactor. An ItemID of 0 is a meso drop
+// with MinQuantity..MaxQuantity mesos.
+type DropEntry struct {
+	ID          uint           `gorm:"primaryKey"`
+	SourceType  DropSourceType `gorm:"index:idx_drop_entries_source,priority:1;not null"`
+	SourceID    int32          `gorm:"index:idx_drop_entries_source,priority:2;not null"` // Mob or reactor template ID
+	ItemID      int32          `gorm:"not null;default:0"`
+	MinQuantity int32          `gorm:"not null;default:1"`
+	MaxQuantity int32          `gorm:"not null;default:1"`
+	Chance      int32          `gorm:"not null;default:0"` // Out of DropChanceMax
+	QuestID     int32          `gorm:"not null;default:0"` // Only dropped while this quest is in progress
+}
+
+func (DropEntry) TableName() string { return "drop_entries" }
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Guild grades. Lower grades outrank higher ones.
+const (
+	GuildGradeMaster   byte = 1
+	GuildGradeJrMaster byte = 2
+	GuildGradeMember   byte = 3
+	GuildGradeLowest   byte = 5
+)
+
+// Guild is a world-wide group of characters. Members point at it through
+// Character.GuildID and hold their rank in Character.GuildGrade.
+type Guild struct {
+	ID      uint `gorm:"primaryKey"`
+	WorldID byte `gorm:"uniqueIndex:ux_world_guild_name;not null"`
+
+	Name      string `gorm:"size:12;not null"`
+	NameIndex string `gorm:"size:12;uniqueIndex:ux_world_guild_name;not null"` // lower(name)
+	MasterID  uint   `gorm:"index;not null"`
+
+	// Rank titles, one per grade
+	GradeName1 string `gorm:"size:12;not null"`
+	GradeName2 string `gorm:"size:12;not null"`
+	GradeName3 string `gorm:"size:12;not null"`
+	GradeName4 string `gorm:"size:12;not null"`
+	GradeName5 string `gorm:"size:12;not null"`
+
+	Capacity int32  `gorm:"default:10;not null"`
+	Notice   string `gorm:"size:100;not null"`
+	Points   int32  `gorm:"default:0;not null"`
+
+	// Emblem
+	MarkBg      int16 `gorm:"default:0;not null"`
+	MarkBgColor byte  `gorm:"default:0;not null"`
+	Mark        int16 `gorm:"default:0;not null"`
+	MarkColor   byte  `gorm:"default:0;not null"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Guild) TableName() string { return "guilds" }
+
+func (g *Guild) BeforeSave(tx *gorm.DB) error {
+	g.NameIndex = strings.ToLower(g.Name)
+	return nil
+}
+
+// GradeNames returns the rank titles from master down
+func (g *Guild) GradeNames() [5]string {
+	return [5]string{g.GradeName1, g.GradeName2, g.GradeName3, g.GradeName4, g.GradeName5}
+}
+
+// SetGradeNames replaces the rank titles
+func (g *Guild) SetGradeNames(names [5]string) {
+	g.GradeName1, g.GradeName2, g.GradeName3, g.GradeName4, g.GradeName5 = names[0], names[1], names[2], names[3], names[4]
+}
@@ -0,0 +1,40 @@
+package game
+
+// MaxLevel is the highest level a character can reach
+const MaxLevel = 200
+
+// MaxCygnusLevel is the highest level a Cygnus Knight can reach
+const MaxCygnusLevel = 120
+
+// nextLevelEXP is the EXP needed to advance from each level, indexed by level-1
+var nextLevelEXP = [MaxLevel - 1]int32{
+	15, 34, 57, 92, 135, 372, 560, 840, 1242, 1716, // 1-10
+	2360, 3216, 4200, 5460, 7050, 8840, 11040, 13716, 16680, 20216, // 11-20
+	24402, 28980, 34320, 40512, 47216, 54900, 63666, 73080, 83720, 95700, // 21-30
+	108480, 122760, 138666, 155540, 174216, 194832, 216600, 240550, 266682, 294216, // 31-40
+	324240, 356916, 392016, 429672, 470200, 513550, 559810, 609500, 662200, 718450, // 41-50
+	778290, 842000, 909480, 981000, 1056720, 1137000, 1221660, 1311000, 1405300, 1504800, // 51-60
+	1609600, 1720000, 1836200, 1958500, 2087000, 2222100, 2364000, 2513000, 2669300, 2833300, // 61-70
+	3005200, 3185300, 3374000, 3571500, 3778200, 3994500, 4220700, 4457100, 4704200, 4962200, // 71-80
+	5231600, 5512700, 5805900, 6111600, 6430200, 6762200, 7108100, 7468300, 7843300, 8233700, // 81-90
+	8640000, 9062700, 9502400, 9959600, 10435000, 10929000, 11442000, 11975000, 12528000, 13102000, // 91-100
+	13697000, 14314000, 14954000, 15617000, 16304000, 17016000, 17753000, 18516000, 19306000, 20124000, // 101-110
+	20969000, 21843000, 22747000, 23681000, 24645000, 25642000, 26670000, 27732000, 28828000, 29959000, // 111-120
+	31125000, 32328000, 33568000, 34846000, 36162000, 37519000, 38916000, 40355000, 41837000, 43362000, // 121-130
+	44931000, 46547000, 48209000, 49918000, 51676000, 53484000, 55342000, 57252000, 59215000, 61231000, // 131-140
+	63303000, 65431000, 67616000, 69860000, 72164000, 74529000, 76956000, 79446000, 82002000, 84623000, // 141-150
+	87311000, 90069000, 92896000, 95795000, 98766000, 101812000, 104933000, 108131000, 111408000, 114764000, // 151-160
+	118202000, 121723000, 125328000, 129020000, 132799000, 136667000, 140627000, 144678000, 148824000, 153066000, // 161-170
+	157405000, 161843000, 166382000, 171023000, 175769000, 180621000, 185581000, 190651000, 195832000, 201127000, // 171-180
+	206537000, 212064000, 217710000, 223477000, 229367000, 235381000, 241522000, 247792000, 254192000, 260725000, // 181-190
+	267393000, 274198000, 281142000, 288228000, 295456000, 302830000, 310352000, 318023000, 325846000, // 191-199
+}
+
+// NextLevelEXP returns the EXP needed to advance from the given level,
+// or 0 at the level cap
+func NextLevelEXP(level int) int32 {
+	if level < 1 || level >= MaxLevel {
+		return 0
+	}
+	return nextLevelEXP[level-1]
+}
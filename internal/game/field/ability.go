@@ -0,0 +1,249 @@
+package field
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+)
+
+const (
+	// maxBaseStat is the cap on STR, DEX, INT and LUK
+	maxBaseStat = 999
+	// minBaseStat is the floor an AP reset can take STR, DEX, INT and LUK down to
+	minBaseStat = 4
+
+	// APResetItemID is the cash item that moves one ability point between stats
+	APResetItemID = 5050000
+)
+
+// SpendAP spends ability points on stats, given as stat flag -> points. Either every
+// increase is applied or none are. Returns the changed stats, including the AP left.
+func (c *Character) SpendAP(points map[int32]int16) (map[int32]int64, error) {
+	if c.model == nil {
+		return nil, fmt.Errorf("character has no model")
+	}
+	m := c.model
+
+	var total int32
+	for flag, n := range points {
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid amount %d for stat 0x%x", n, flag)
+		}
+		total += int32(n)
+
+		var current int32
+		switch flag {
+		case packets.StatSTR:
+			current = int32(m.STR)
+		case packets.StatDEX:
+			current = int32(m.DEX)
+		case packets.StatINT:
+			current = int32(m.INT)
+		case packets.StatLUK:
+			current = int32(m.LUK)
+		case packets.StatMaxHP, packets.StatMaxMP:
+			continue
+		default:
+			return nil, fmt.Errorf("stat 0x%x cannot take AP", flag)
+		}
+		if current+int32(n) > maxBaseStat {
+			return nil, fmt.Errorf("stat 0x%x would exceed %d", flag, maxBaseStat)
+		}
+	}
+	if total > int32(m.AP) {
+		return nil, fmt.Errorf("spending %d AP with only %d", total, m.AP)
+	}
+
+	changed := make(map[int32]int64)
+	job := game.Job(m.Job)
+	for flag, n := range points {
+		switch flag {
+		case packets.StatSTR:
+			m.STR += n
+			changed[flag] = int64(m.STR)
+		case packets.StatDEX:
+			m.DEX += n
+			changed[flag] = int64(m.DEX)
+		case packets.StatINT:
+			m.INT += n
+			changed[flag] = int64(m.INT)
+		case packets.StatLUK:
+			m.LUK += n
+			changed[flag] = int64(m.LUK)
+		case packets.StatMaxHP:
+			for range n {
+				m.MaxHP = min(m.MaxHP+apHPGain(job), maxHPMP)
+			}
+			changed[flag] = int64(m.MaxHP)
+		case packets.StatMaxMP:
+			for range n {
+				m.MaxMP = min(m.MaxMP+apMPGain(job, int32(m.INT)), maxHPMP)
+			}
+			changed[flag] = int64(m.MaxMP)
+		}
+	}
+
+	m.AP -= int16(total)
+	changed[packets.StatAP] = int64(m.AP)
+	c.RecalcStats()
+	return changed, nil
+}
+
+// apHPMP holds a job branch's max HP and MP rules for ability points
+type apHPMP struct {
+	hpGain, mpGain         [2]int32 // Lowest and highest roll for one AP
+	hpReset, mpReset       int32    // Taken back when an AP is reset out of the stat
+	hpPerLevel, mpPerLevel int32    // Floor on max HP/MP gained per level
+}
+
+// apHPMPRules are the per-branch HP/MP rules, by job category
+var apHPMPRules = map[int]apHPMP{
+	0: {hpGain: [2]int32{8, 12}, mpGain: [2]int32{6, 8}, hpReset: 8, mpReset: 6, hpPerLevel: 12, mpPerLevel: 10},      // Beginner
+	1: {hpGain: [2]int32{20, 24}, mpGain: [2]int32{2, 4}, hpReset: 20, mpReset: 2, hpPerLevel: 24, mpPerLevel: 4},     // Warrior
+	2: {hpGain: [2]int32{6, 10}, mpGain: [2]int32{18, 20}, hpReset: 6, mpReset: 18, hpPerLevel: 10, mpPerLevel: 22},   // Magician
+	3: {hpGain: [2]int32{16, 20}, mpGain: [2]int32{10, 12}, hpReset: 16, mpReset: 10, hpPerLevel: 20, mpPerLevel: 14}, // Bowman
+	4: {hpGain: [2]int32{16, 20}, mpGain: [2]int32{10, 12}, hpReset: 16, mpReset: 10, hpPerLevel: 20, mpPerLevel: 14}, // Thief
+	5: {hpGain: [2]int32{18, 22}, mpGain: [2]int32{14, 16}, hpReset: 18, mpReset: 14, hpPerLevel: 22, mpPerLevel: 18}, // Pirate
+}
+
+// Starting max HP and MP, the base of the per-level floors
+const (
+	baseMaxHP = 50
+	baseMaxMP = 5
+)
+
+// hpmpRules returns the HP/MP rules for a job
+func hpmpRules(job game.Job) apHPMP {
+	if rules, ok := apHPMPRules[job.Category()]; ok && !job.IsBeginner() {
+		return rules
+	}
+	return apHPMPRules[0]
+}
+
+// apHPGain rolls the max HP gained from one AP for a job branch
+func apHPGain(job game.Job) int32 {
+	gain := hpmpRules(job).hpGain
+	return gain[0] + rand.Int32N(gain[1]-gain[0]+1)
+}
+
+// apMPGain rolls the max MP gained from one AP for a job branch.
+// Magicians also gain MP from their INT.
+func apMPGain(job game.Job, intl int32) int32 {
+	gain := hpmpRules(job).mpGain
+	mp := gain[0] + rand.Int32N(gain[1]-gain[0]+1)
+	if job.Category() == 2 && !job.IsBeginner() {
+		mp += intl / 20
+	}
+	return mp
+}
+
+// minMaxHPMP returns the lowest max HP and MP a character of the job may be
+// reset down to at a level
+func minMaxHPMP(job game.Job, level int32) (hp, mp int32) {
+	rules := hpmpRules(job)
+	return baseMaxHP + rules.hpPerLevel*(level-1), baseMaxMP + rules.mpPerLevel*(level-1)
+}
+
+// ResetAP uses an AP reset item to move one ability point from one stat to
+// another. Max HP and MP can't be reset below the job's floor for the level.
+// Returns the changed stats.
+func (c *Character) ResetAP(itemSlot int16, to, from int32) (map[int32]int64, error) {
+	if c.model == nil {
+		return nil, fmt.Errorf("character has no model")
+	}
+	m := c.model
+	job := game.Job(m.Job)
+	rules := hpmpRules(job)
+	if to == from {
+		return nil, fmt.Errorf("AP reset from and to the same stat 0x%x", to)
+	}
+
+	baseStat := func(flag int32) *int16 {
+		switch flag {
+		case packets.StatSTR:
+			return &m.STR
+		case packets.StatDEX:
+			return &m.DEX
+		case packets.StatINT:
+			return &m.INT
+		case packets.StatLUK:
+			return &m.LUK
+		}
+		return nil
+	}
+
+	minHP, minMP := minMaxHPMP(job, int32(m.Level))
+	switch from {
+	case packets.StatMaxHP:
+		if m.MaxHP-rules.hpReset < minHP {
+			return nil, fmt.Errorf("max HP %d can't go below %d", m.MaxHP, minHP)
+		}
+	case packets.StatMaxMP:
+		if m.MaxMP-rules.mpReset < minMP {
+			return nil, fmt.Errorf("max MP %d can't go below %d", m.MaxMP, minMP)
+		}
+	default:
+		stat := baseStat(from)
+		if stat == nil {
+			return nil, fmt.Errorf("stat 0x%x cannot give AP", from)
+		}
+		if *stat <= minBaseStat {
+			return nil, fmt.Errorf("stat 0x%x is already at %d", from, minBaseStat)
+		}
+	}
+	switch to {
+	case packets.StatMaxHP, packets.StatMaxMP:
+	default:
+		stat := baseStat(to)
+		if stat == nil {
+			return nil, fmt.Errorf("stat 0x%x cannot take AP", to)
+		}
+		if *stat >= maxBaseStat {
+			return nil, fmt.Errorf("stat 0x%x is already at %d", to, maxBaseStat)
+		}
+	}
+
+	c.posMu.Lock()
+	reset := c.itemAtLocked(models.InvCash, itemSlot)
+	if reset == nil || reset.ItemID != APResetItemID {
+		c.posMu.Unlock()
+		return nil, fmt.Errorf("no AP reset in slot %d", itemSlot)
+	}
+	op := c.consumeOneLocked(reset)
+	c.posMu.Unlock()
+	c.Write(packets.InventoryOperationPacket(true, []packets.InventoryOperation{op}))
+
+	changed := make(map[int32]int64)
+	switch from {
+	case packets.StatMaxHP:
+		m.MaxHP -= rules.hpReset
+		m.HP = min(m.HP, m.MaxHP)
+		changed[packets.StatMaxHP] = int64(m.MaxHP)
+		changed[packets.StatHP] = int64(m.HP)
+	case packets.StatMaxMP:
+		m.MaxMP -= rules.mpReset
+		m.MP = min(m.MP, m.MaxMP)
+		changed[packets.StatMaxMP] = int64(m.MaxMP)
+		changed[packets.StatMP] = int64(m.MP)
+	default:
+		*baseStat(from)--
+		changed[from] = int64(*baseStat(from))
+	}
+	switch to {
+	case packets.StatMaxHP:
+		m.MaxHP = min(m.MaxHP+apHPGain(job), maxHPMP)
+		changed[packets.StatMaxHP] = int64(m.MaxHP)
+	case packets.StatMaxMP:
+		m.MaxMP = min(m.MaxMP+apMPGain(job, int32(m.INT)), maxHPMP)
+		changed[packets.StatMaxMP] = int64(m.MaxMP)
+	default:
+		*baseStat(to)++
+		changed[to] = int64(*baseStat(to))
+	}
+
+	c.RecalcStats()
+	return changed, nil
+}
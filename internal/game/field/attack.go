@@ -0,0 +1,158 @@
+package field
+
+import "github.com/Jinw00Arise/Jinwoo/internal/protocol"
+
+// AttackType identifies which attack request an AttackInfo was decoded from
+type AttackType byte
+
+const (
+	AttackTypeMelee AttackType = iota
+	AttackTypeShoot
+	AttackTypeMagic
+)
+
+// criticalFlag marks a critical damage line in the client's damage values
+const criticalFlag = 0x80000000
+
+// keydownSkills are charged skills whose attack packets carry an extra tKeyDown
+var keydownSkills = map[int32]bool{
+	2121001:  true, // Big Bang (F/P)
+	2221001:  true, // Big Bang (I/L)
+	2321001:  true, // Big Bang (Bishop)
+	3121004:  true, // Hurricane
+	3221001:  true, // Piercing Arrow
+	4341002:  true, // Final Cut
+	4341003:  true, // Monster Bomb
+	5101004:  true, // Corkscrew Blow
+	5201002:  true, // Grenade
+	5221004:  true, // Rapid Fire
+	13111002: true, // Hurricane (Wind Archer)
+	14111006: true, // Poison Bomb
+	15101003: true, // Corkscrew Blow (Thunder Breaker)
+	22121000: true, // Fire Breath
+	22151001: true, // Ice Breath
+	33101005: true, // Swallow
+	33121009: true, // Wild Arrow Blast
+	35001001: true, // Flame Launcher
+	35101009: true, // Enhanced Flame Launcher
+}
+
+// IsKeydownSkill returns true if the skill is charged by holding the key down
+func IsKeydownSkill(skillID int32) bool {
+	return keydownSkills[skillID]
+}
+
+// DamageLine is a single hit on a mob
+type DamageLine struct {
+	Damage   int32
+	Critical bool
+}
+
+// MobAttackInfo holds the hits one attack landed on a single mob
+type MobAttackInfo struct {
+	ObjectID  int32
+	HitAction byte
+	Lines     []DamageLine
+}
+
+// TotalDamage sums all damage lines on the mob
+func (m *MobAttackInfo) TotalDamage() int64 {
+	var total int64
+	for _, line := range m.Lines {
+		total += int64(line.Damage)
+	}
+	return total
+}
+
+// AttackInfo is a decoded melee, shoot or magic attack request
+type AttackInfo struct {
+	Type         AttackType
+	Mask         byte // nDamagePerMob | nMobCount << 4
+	SkillID      int32
+	CombatOrders byte
+	KeyDown      int32
+	Flag         byte
+	ActionAndDir uint16 // nAttackAction & 0x7FFF | bLeft << 15
+	ActionType   byte
+	AttackSpeed  byte
+	AttackTime   int32
+	BulletSlot   int16
+	Mobs         []MobAttackInfo
+}
+
+// MobCount returns how many mobs the attack hit
+func (a *AttackInfo) MobCount() int {
+	return int(a.Mask >> 4)
+}
+
+// DamagePerMob returns how many damage lines each mob received
+func (a *AttackInfo) DamagePerMob() int {
+	return int(a.Mask & 0x0F)
+}
+
+// DecodeAttack reads a v95 melee, shoot or magic attack request
+func DecodeAttack(reader *protocol.Reader, attackType AttackType) *AttackInfo {
+	attack := &AttackInfo{Type: attackType}
+
+	_ = reader.ReadByte() // bFieldKey
+	_ = reader.ReadInt()  // ~drInfo.dr0
+	_ = reader.ReadInt()  // ~drInfo.dr1
+	attack.Mask = reader.ReadByte()
+	_ = reader.ReadInt() // ~drInfo.dr2
+	_ = reader.ReadInt() // ~drInfo.dr3
+	attack.SkillID = reader.ReadInt()
+	attack.CombatOrders = reader.ReadByte()
+	_ = reader.ReadInt() // dwKey
+	_ = reader.ReadInt() // Crc32
+	_ = reader.ReadInt() // SKILLLEVELDATA::GetCrC
+	_ = reader.ReadInt() // SKILLLEVELDATA::GetCrC
+
+	if IsKeydownSkill(attack.SkillID) {
+		attack.KeyDown = reader.ReadInt()
+	}
+
+	attack.Flag = reader.ReadByte()
+	if attackType == AttackTypeShoot {
+		_ = reader.ReadByte() // bNextShootExJablin
+	}
+	attack.ActionAndDir = reader.ReadShort()
+	_ = reader.ReadInt() // GETCRC32Svr
+	attack.ActionType = reader.ReadByte()
+	attack.AttackSpeed = reader.ReadByte()
+	attack.AttackTime = reader.ReadInt()
+	_ = reader.ReadInt() // dwID
+
+	if attackType == AttackTypeShoot {
+		attack.BulletSlot = int16(reader.ReadShort())
+		_ = reader.ReadShort() // pnCashItemPos
+		_ = reader.ReadByte()  // nShootRange0a
+	}
+
+	damagePerMob := attack.DamagePerMob()
+	attack.Mobs = make([]MobAttackInfo, attack.MobCount())
+	for i := range attack.Mobs {
+		mob := &attack.Mobs[i]
+		mob.ObjectID = reader.ReadInt()
+		mob.HitAction = reader.ReadByte()
+		_ = reader.ReadByte()  // nForeAction & 0x7F | bLeft << 7
+		_ = reader.ReadByte()  // nFrameIdx
+		_ = reader.ReadByte()  // nCalcDamageStatIndex | bCurTemplate << 7
+		_ = reader.ReadShort() // ptHit.x
+		_ = reader.ReadShort() // ptHit.y
+		_ = reader.ReadShort() // ptPosPrev.x
+		_ = reader.ReadShort() // ptPosPrev.y
+		_ = reader.ReadShort() // tDelay
+
+		mob.Lines = make([]DamageLine, damagePerMob)
+		for j := range mob.Lines {
+			raw := uint32(reader.ReadInt())
+			mob.Lines[j] = DamageLine{
+				Damage:   int32(raw &^ criticalFlag),
+				Critical: raw&criticalFlag != 0,
+			}
+		}
+		_ = reader.ReadInt() // CMob::GetCrc
+	}
+
+	return attack
+}
@@ -0,0 +1,155 @@
+package field
+
+import (
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers"
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers/item"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+)
+
+// skillOptionX lists buff skills whose special stat takes the level's x value
+var skillOptionX = map[int32]packets.CharacterTemporaryStat{
+	// Boosters
+	1101004: packets.CTSBooster, 1101005: packets.CTSBooster,
+	1201004: packets.CTSBooster, 1201005: packets.CTSBooster,
+	1301004: packets.CTSBooster, 1301005: packets.CTSBooster,
+	2111005: packets.CTSBooster, 2211005: packets.CTSBooster,
+	3101002: packets.CTSBooster, 3201002: packets.CTSBooster,
+	4101003: packets.CTSBooster, 4201002: packets.CTSBooster,
+	5101006: packets.CTSBooster, 5201003: packets.CTSBooster,
+	11101001: packets.CTSBooster, 12101004: packets.CTSBooster, 13101001: packets.CTSBooster,
+	14101002: packets.CTSBooster, 15101002: packets.CTSBooster, 21001003: packets.CTSBooster,
+
+	// Maple Warrior
+	1121000: packets.CTSBasicStatUp, 1221000: packets.CTSBasicStatUp, 1321000: packets.CTSBasicStatUp,
+	2121000: packets.CTSBasicStatUp, 2221000: packets.CTSBasicStatUp, 2321000: packets.CTSBasicStatUp,
+	3121000: packets.CTSBasicStatUp, 3221000: packets.CTSBasicStatUp,
+	4121000: packets.CTSBasicStatUp, 4221000: packets.CTSBasicStatUp,
+	5121000: packets.CTSBasicStatUp, 5221000: packets.CTSBasicStatUp, 21121000: packets.CTSBasicStatUp,
+
+	2001002:  packets.CTSMagicGuard,
+	12001001: packets.CTSMagicGuard,
+	4001003:  packets.CTSDarkSight,
+	14001003: packets.CTSDarkSight,
+	1101007:  packets.CTSPowerGuard,
+	1201007:  packets.CTSPowerGuard,
+	2301003:  packets.CTSInvincible,
+	3101004:  packets.CTSSoulArrow,
+	3201004:  packets.CTSSoulArrow,
+	13101003: packets.CTSSoulArrow,
+	2311003:  packets.CTSHolySymbol,
+	4111001:  packets.CTSMesoUp,
+	4111002:  packets.CTSShadowPartner,
+	14111000: packets.CTSShadowPartner,
+	4211005:  packets.CTSMesoGuard,
+	3121008:  packets.CTSConcentration,
+	2121004:  packets.CTSInfinity,
+	2221004:  packets.CTSInfinity,
+	2321004:  packets.CTSInfinity,
+	2321005:  packets.CTSHolyShield,
+}
+
+// skillMobStatus lists attack skills that inflict a status on the mobs they hit
+var skillMobStatus = map[int32]packets.MobTemporaryStat{
+	1111005: packets.MobStatStun, // Coma: Sword
+	1111006: packets.MobStatStun, // Coma: Axe
+	1111008: packets.MobStatStun, // Shout
+	3101005: packets.MobStatStun, // Arrow Bomb
+	4211002: packets.MobStatStun, // Assaulter
+
+	2201004: packets.MobStatFreeze, // Cold Beam
+	2211002: packets.MobStatFreeze, // Ice Strike
+	2221007: packets.MobStatFreeze, // Blizzard
+	3211003: packets.MobStatFreeze, // Blizzard (crossbow)
+}
+
+// Buff skills with options that don't come from x alone
+const (
+	skillHyperBody     = 1301007
+	skillCombo         = 1111002
+	skillCygnusCombo   = 11111001
+	skillSharpEyesBow  = 3121002
+	skillSharpEyesXbow = 3221002
+	skillStanceHero    = 1121002
+	skillStancePaladin = 1221002
+	skillStanceDrk     = 1321002
+	skillStanceAran    = 21121003
+)
+
+// SkillBuffStats returns the temporary stats a buff skill grants at a level
+func SkillBuffStats(skillID int32, lv *providers.SkillLevel) map[packets.CharacterTemporaryStat]int32 {
+	stats := basicBuffStats(lv.PAD, lv.PDD, lv.MAD, lv.MDD, lv.ACC, lv.EVA, lv.Speed, lv.Jump)
+
+	if stat, ok := skillOptionX[skillID]; ok {
+		stats[stat] = lv.X
+	}
+
+	switch skillID {
+	case skillHyperBody:
+		stats[packets.CTSMaxHP] = lv.X
+		stats[packets.CTSMaxMP] = lv.Y
+	case skillCombo, skillCygnusCombo:
+		stats[packets.CTSComboCounter] = 1
+	case skillSharpEyesBow, skillSharpEyesXbow:
+		stats[packets.CTSSharpEyes] = lv.X<<8 | lv.Y
+	case skillStanceHero, skillStancePaladin, skillStanceDrk, skillStanceAran:
+		stats[packets.CTSStance] = lv.Prop
+	}
+
+	return stats
+}
+
+// SkillBuffDuration returns how long a skill's buff lasts at a level
+func SkillBuffDuration(lv *providers.SkillLevel) time.Duration {
+	return time.Duration(lv.Time) * time.Second
+}
+
+// SkillMobStats returns the statuses an attack skill inflicts on the mobs it hits
+func SkillMobStats(skillID int32) map[packets.MobTemporaryStat]int32 {
+	stat, ok := skillMobStatus[skillID]
+	if !ok {
+		return nil
+	}
+	return map[packets.MobTemporaryStat]int32{stat: 1}
+}
+
+// ItemBuffStats returns the temporary stats a stat-change item grants
+func ItemBuffStats(info *item.ItemInfo) map[packets.CharacterTemporaryStat]int32 {
+	return basicBuffStats(
+		info.GetSpecOr(item.KeySpecPad, 0), info.GetSpecOr(item.KeySpecPdd, 0),
+		info.GetSpecOr(item.KeySpecMad, 0), info.GetSpecOr(item.KeySpecMdd, 0),
+		info.GetSpecOr(item.KeySpecAcc, 0), info.GetSpecOr(item.KeySpecEva, 0),
+		info.GetSpecOr(item.KeySpecSpeed, 0), info.GetSpecOr(item.KeySpecJump, 0),
+	)
+}
+
+// ItemBuffDuration returns how long a stat-change item's buff lasts
+func ItemBuffDuration(info *item.ItemInfo) time.Duration {
+	return time.Duration(info.GetSpecOr(item.KeySpecTime, 0)) * time.Millisecond
+}
+
+// ApplyItemBuff applies a stat-change item's timed buff, returning false if
+// the item doesn't grant one
+func (c *Character) ApplyItemBuff(info *item.ItemInfo) bool {
+	stats := ItemBuffStats(info)
+	duration := ItemBuffDuration(info)
+	if len(stats) == 0 || duration <= 0 {
+		return false
+	}
+	c.SetTemporaryStats(-info.GetItemID(), duration, stats)
+	return true
+}
+
+func basicBuffStats(pad, pdd, mad, mdd, acc, eva, speed, jump int32) map[packets.CharacterTemporaryStat]int32 {
+	stats := make(map[packets.CharacterTemporaryStat]int32)
+	for stat, v := range map[packets.CharacterTemporaryStat]int32{
+		packets.CTSPAD: pad, packets.CTSPDD: pdd, packets.CTSMAD: mad, packets.CTSMDD: mdd,
+		packets.CTSACC: acc, packets.CTSEVA: eva, packets.CTSSpeed: speed, packets.CTSJump: jump,
+	} {
+		if v != 0 {
+			stats[stat] = v
+		}
+	}
+	return stats
+}
@@ -0,0 +1,235 @@
+package field
+
+import (
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers/item"
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+)
+
+const (
+	baseSpeed = 100
+	maxSpeed  = 140
+	baseJump  = 100
+	maxJump   = 123
+
+	// maxCalcHPMP is the cap on max HP and MP once equips and buffs are added
+	maxCalcHPMP = 99999
+
+	// baseMastery is the weapon mastery without any mastery skill
+	baseMastery = 0.1
+
+	// WalkVelocity is how many pixels per second a character walks at 100% speed
+	WalkVelocity = 125
+)
+
+// StatBonus are stat props from potentials and set effects, keyed by their WZ property name
+// (incSTR, incPADr, ...). Props ending in r are percentages of the matching flat stat.
+type StatBonus map[string]int32
+
+// Apply adds the flat and percentage bonus for a stat, e.g. Apply(base, "incSTR")
+// adds incSTR and then incSTRr percent
+func (b StatBonus) Apply(base int32, key string) int32 {
+	return (base + b[key]) * (100 + b[key+"r"]) / 100
+}
+
+func (b StatBonus) add(props map[string]int32) {
+	for prop, value := range props {
+		b[prop] += value
+	}
+}
+
+// CalcStats are a character's derived stats: base stats plus equips, potentials,
+// set effects and buffs
+type CalcStats struct {
+	STR, DEX, INT, LUK int32
+	MaxHP, MaxMP       int32
+
+	PAD, MAD int32 // Weapon and magic attack
+	PDD, MDD int32 // Weapon and magic defence
+	ACC, EVA int32 // Accuracy and avoidability
+
+	Speed, Jump int32
+
+	WeaponID  int32
+	DamageMin int32 // Physical damage range before skills
+	DamageMax int32
+	MagicMax  int32
+}
+
+// CalcStats returns the character's last computed derived stats
+func (c *Character) CalcStats() CalcStats {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+	return c.calcStats
+}
+
+// RecalcStats recomputes the character's derived stats. Call it after anything that
+// changes base stats, equipment or buffs. HP and MP above a lowered max are cut
+// back and the owner is told.
+func (c *Character) RecalcStats() {
+	m := c.model
+	if m == nil {
+		return
+	}
+
+	s, oldMaxHP := c.updateCalcStats()
+
+	clamped := make(map[int32]int64)
+	if m.HP > s.MaxHP {
+		m.HP = s.MaxHP
+		clamped[packets.StatHP] = int64(m.HP)
+	}
+	if m.MP > s.MaxMP {
+		m.MP = s.MaxMP
+		clamped[packets.StatMP] = int64(m.MP)
+	}
+	if len(clamped) > 0 {
+		c.Write(packets.StatChanged(false, clamped))
+	}
+	if _, hpClamped := clamped[packets.StatHP]; hpClamped || s.MaxHP != oldMaxHP {
+		c.BroadcastPartyHP()
+	}
+}
+
+// updateCalcStats recomputes and stores the derived stats without touching HP
+// and MP or sending anything. Returns the new stats and the previous max HP.
+func (c *Character) updateCalcStats() (CalcStats, int32) {
+	m := c.model
+	if m == nil {
+		return CalcStats{}, 0
+	}
+
+	equipped := c.Equipped()
+	temp := c.TemporaryStats()
+	option := func(stat packets.CharacterTemporaryStat) int32 {
+		return temp[stat].Option
+	}
+
+	bonus := c.PotentialStats()
+	bonus.add(c.setItemBonus(equipped))
+
+	var s CalcStats
+	s.STR, s.DEX, s.INT, s.LUK = int32(m.STR), int32(m.DEX), int32(m.INT), int32(m.LUK)
+
+	// Maple Warrior raises the AP-assigned stats by a percentage
+	if rate := option(packets.CTSBasicStatUp); rate > 0 {
+		s.STR += s.STR * rate / 100
+		s.DEX += s.DEX * rate / 100
+		s.INT += s.INT * rate / 100
+		s.LUK += s.LUK * rate / 100
+	}
+
+	s.MaxHP, s.MaxMP = m.MaxHP, m.MaxMP
+	s.Speed, s.Jump = baseSpeed, baseJump
+	for _, it := range equipped {
+		s.STR += int32(it.IncStr)
+		s.DEX += int32(it.IncDex)
+		s.INT += int32(it.IncInt)
+		s.LUK += int32(it.IncLuk)
+		s.MaxHP += int32(it.IncMaxHP)
+		s.MaxMP += int32(it.IncMaxMP)
+		s.PAD += int32(it.IncPAD)
+		s.MAD += int32(it.IncMAD)
+		s.PDD += int32(it.IncPDD)
+		s.MDD += int32(it.IncMDD)
+		s.ACC += int32(it.IncACC)
+		s.EVA += int32(it.IncEVA)
+		s.Speed += int32(it.IncSpeed)
+		s.Jump += int32(it.IncJump)
+		if it.Slot == models.EquipSlotWeapon {
+			s.WeaponID = it.ItemID
+		}
+	}
+
+	s.STR = bonus.Apply(s.STR, "incSTR")
+	s.DEX = bonus.Apply(s.DEX, "incDEX")
+	s.INT = bonus.Apply(s.INT, "incINT")
+	s.LUK = bonus.Apply(s.LUK, "incLUK")
+
+	s.MaxHP = bonus.Apply(s.MaxHP, "incMHP")
+	s.MaxMP = bonus.Apply(s.MaxMP, "incMMP")
+	// Hyper Body raises max HP and MP by a percentage
+	s.MaxHP = min(s.MaxHP*(100+option(packets.CTSMaxHP))/100, maxCalcHPMP)
+	s.MaxMP = min(s.MaxMP*(100+option(packets.CTSMaxMP))/100, maxCalcHPMP)
+
+	s.PAD = bonus.Apply(s.PAD+option(packets.CTSPAD), "incPAD")
+	s.MAD = bonus.Apply(s.MAD+option(packets.CTSMAD), "incMAD")
+	s.PDD = bonus.Apply(s.PDD+option(packets.CTSPDD), "incPDD")
+	s.MDD = bonus.Apply(s.MDD+option(packets.CTSMDD), "incMDD")
+	s.ACC = bonus.Apply(s.ACC+option(packets.CTSACC)+s.DEX*4/5+s.LUK/2, "incACC")
+	s.EVA = bonus.Apply(s.EVA+option(packets.CTSEVA)+s.LUK/2+s.DEX/4, "incEVA")
+
+	s.Speed = min(bonus.Apply(s.Speed+option(packets.CTSSpeed), "incSpeed"), maxSpeed)
+	s.Jump = min(bonus.Apply(s.Jump+option(packets.CTSJump), "incJump"), maxJump)
+
+	multiplier, primary, secondary := WeaponDamageStats(s.WeaponID, s.STR, s.DEX, s.LUK)
+	pad := float64(max(s.PAD, 1))
+	s.DamageMax = int32((float64(primary)*multiplier + float64(secondary)) * pad / 100)
+	s.DamageMin = int32((float64(primary)*multiplier*0.9*baseMastery + float64(secondary)) * pad / 100)
+	s.MagicMax = int32(float64(s.INT*4+s.LUK) * float64(max(s.MAD, 1)) / 100)
+
+	c.posMu.Lock()
+	oldMaxHP := c.calcStats.MaxHP
+	c.calcStats = s
+	c.posMu.Unlock()
+	return s, oldMaxHP
+}
+
+// setItemBonus returns the set effects unlocked by the worn parts of each equipment set
+func (c *Character) setItemBonus(equipped []*models.CharacterItem) StatBonus {
+	bonus := make(StatBonus)
+	if c.itemProvider == nil {
+		return bonus
+	}
+
+	parts := make(map[int32]int32)
+	for _, it := range equipped {
+		if info := c.itemProvider.GetItemInfo(it.ItemID); info != nil {
+			if setID := info.GetInfoOr(item.KeySetItemID, 0); setID != 0 {
+				parts[setID]++
+			}
+		}
+	}
+
+	for setID, count := range parts {
+		set := c.itemProvider.GetSetItemInfo(setID)
+		if set == nil {
+			continue
+		}
+		for _, props := range set.GetEffects(count) {
+			bonus.add(props)
+		}
+	}
+	return bonus
+}
+
+// WeaponDamageStats returns the weapon's damage multiplier and the primary and
+// secondary stats it scales with
+func WeaponDamageStats(weaponID, str, dex, luk int32) (float64, int32, int32) {
+	switch weaponID / 10000 % 100 {
+	case 30: // One-handed sword
+		return 4.0, str, dex
+	case 31, 32: // One-handed axe / blunt weapon
+		return 4.4, str, dex
+	case 33: // Dagger
+		return 3.6, luk, str + dex
+	case 40: // Two-handed sword
+		return 4.6, str, dex
+	case 41, 42: // Two-handed axe / blunt weapon
+		return 4.8, str, dex
+	case 43, 44: // Spear / polearm
+		return 5.0, str, dex
+	case 45: // Bow
+		return 3.4, dex, str
+	case 46: // Crossbow
+		return 3.6, dex, str
+	case 47: // Claw
+		return 3.6, luk, str + dex
+	case 48: // Knuckle
+		return 4.8, str, dex
+	case 49: // Gun
+		return 3.6, dex, str
+	default:
+		return 4.0, str, dex
+	}
+}
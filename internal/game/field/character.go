@@ -3,8 +3,12 @@ package field
 import (
 	"log"
 	"sync"
+	"time"
 
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers"
 	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/quest"
 	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
 )
 
@@ -17,8 +21,22 @@ type Character struct {
 	model *models.Character // Database model
 	items []*models.CharacterItem
 
+	itemProvider *providers.ItemProvider
+
 	// Quest tracking
 	questRecords []*models.QuestRecord
+	questManager *quest.CharacterQuestManager
+
+	// Skill tracking
+	skills    map[int32]*models.Skill
+	macros    []*models.SkillMacro
+	cooldowns map[int32]time.Time
+
+	// Active buffs and debuffs
+	tempStats packets.TemporaryStats
+
+	// Derived stats, refreshed by RecalcStats
+	calcStats CalcStats
 
 	field      *Field
 	fieldKey   byte
@@ -26,16 +44,25 @@ type Character struct {
 	posY       uint16
 	foothold   uint16
 	moveAction byte
+	hidden     bool // GM hide
+
+	// Guild name and emblem shown over the character's head
+	guildName string
+	guildMark packets.GuildMark
 
 	posMu sync.RWMutex
 }
 
 // NewCharacter creates a new character instance linked to a user session
-func NewCharacter(user *User, model *models.Character) *Character {
+func NewCharacter(user *User, model *models.Character, itemProvider *providers.ItemProvider) *Character {
 	return &Character{
-		user:     user,
-		model:    model,
-		fieldKey: 1,
+		user:         user,
+		model:        model,
+		itemProvider: itemProvider,
+		skills:       make(map[int32]*models.Skill),
+		cooldowns:    make(map[int32]time.Time),
+		tempStats:    make(packets.TemporaryStats),
+		fieldKey:     1,
 	}
 }
 
@@ -87,6 +114,20 @@ func (c *Character) SetField(f *Field) {
 	c.field = f
 }
 
+// Hidden reports whether the character is hidden by GM hide
+func (c *Character) Hidden() bool {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+	return c.hidden
+}
+
+// SetHidden sets whether the character is hidden by GM hide
+func (c *Character) SetHidden(hidden bool) {
+	c.posMu.Lock()
+	defer c.posMu.Unlock()
+	c.hidden = hidden
+}
+
 // FieldKey returns the current field key
 func (c *Character) FieldKey() byte {
 	c.posMu.RLock()
@@ -199,11 +240,15 @@ func (c *Character) Items() []*models.CharacterItem {
 	return cpy
 }
 
-// SetItems sets the character's items
+// SetItems sets the character's items and recalculates the stats they give.
+// Nothing is sent; call RecalcStats once the character is in a field to cut
+// HP and MP back to the new max.
 func (c *Character) SetItems(items []*models.CharacterItem) {
 	c.posMu.Lock()
-	defer c.posMu.Unlock()
 	c.items = items
+	c.posMu.Unlock()
+
+	c.updateCalcStats()
 }
 
 // TransferToField handles the logic of moving a character between fields.
@@ -278,6 +323,14 @@ func (c *Character) Job() int16 {
 	return c.model.Job
 }
 
+// FriendMax returns how many buddies fit on the character's buddy list
+func (c *Character) FriendMax() int {
+	if c.model == nil || c.model.FriendMax <= 0 {
+		return packets.DefaultFriendMax
+	}
+	return int(c.model.FriendMax)
+}
+
 // STR returns the character's STR stat
 func (c *Character) STR() int16 {
 	if c.model == nil {
@@ -350,6 +403,14 @@ func (c *Character) Mesos() int32 {
 	return c.model.Meso
 }
 
+// PartyID returns the ID of the character's party, or 0 if not in one
+func (c *Character) PartyID() uint {
+	if c.model == nil || c.model.PartyID == nil {
+		return 0
+	}
+	return *c.model.PartyID
+}
+
 // Fame returns the character's fame
 func (c *Character) Fame() int16 {
 	if c.model == nil {
@@ -366,19 +427,11 @@ func (c *Character) EXP() int32 {
 	return c.model.EXP
 }
 
-// GainEXP adds experience to the character
-func (c *Character) GainEXP(exp int32) {
-	if c.model != nil {
-		c.model.EXP += exp
-		// TODO: Check level up, send packet
-	}
-}
-
 // GainMesos adds mesos to the character
 func (c *Character) GainMesos(mesos int32) {
 	if c.model != nil {
 		c.model.Meso += mesos
-		// TODO: Send meso update packet
+		c.Write(packets.StatChanged(false, map[int32]int64{packets.StatMoney: int64(c.model.Meso)}))
 	}
 }
 
@@ -386,7 +439,15 @@ func (c *Character) GainMesos(mesos int32) {
 func (c *Character) GainFame(fame int16) {
 	if c.model != nil {
 		c.model.Fame += fame
-		// TODO: Send fame update packet
+		c.Write(packets.StatChanged(false, map[int32]int64{packets.StatPOP: int64(c.model.Fame)}))
+	}
+}
+
+// GainSP adds skill points to the character
+func (c *Character) GainSP(sp int16) {
+	if c.model != nil {
+		c.model.SP += sp
+		c.Write(packets.StatChanged(false, map[int32]int64{packets.StatSP: int64(c.model.SP)}))
 	}
 }
 
@@ -409,20 +470,6 @@ func (c *Character) ItemCount(itemID int32) int32 {
 	return count
 }
 
-// GainItem gives an item to the character
-func (c *Character) GainItem(itemID int32, count int16) bool {
-	// TODO: Add item to inventory, check space
-	log.Printf("[Character] GainItem: %d x%d (not implemented)", itemID, count)
-	return true
-}
-
-// RemoveItem removes an item from the character
-func (c *Character) RemoveItem(itemID int32, count int16) bool {
-	// TODO: Remove item from inventory
-	log.Printf("[Character] RemoveItem: %d x%d (not implemented)", itemID, count)
-	return true
-}
-
 // QuestRecords returns a copy of the character's quest records
 func (c *Character) QuestRecords() []*models.QuestRecord {
 	c.posMu.RLock()
@@ -440,6 +487,61 @@ func (c *Character) SetQuestRecords(records []*models.QuestRecord) {
 	c.questRecords = records
 }
 
+// QuestManager returns the character's quest manager
+func (c *Character) QuestManager() *quest.CharacterQuestManager {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+	return c.questManager
+}
+
+// SetQuestManager sets the character's quest manager
+func (c *Character) SetQuestManager(m *quest.CharacterQuestManager) {
+	c.posMu.Lock()
+	defer c.posMu.Unlock()
+	c.questManager = m
+}
+
+// SetQuestRecord creates or updates the record for a quest
+func (c *Character) SetQuestRecord(questID uint16, state models.QuestState, progress string) *models.QuestRecord {
+	c.posMu.Lock()
+	defer c.posMu.Unlock()
+
+	var record *models.QuestRecord
+	for _, qr := range c.questRecords {
+		if qr.QuestID == questID {
+			record = qr
+			break
+		}
+	}
+	if record == nil {
+		record = &models.QuestRecord{CharacterID: c.ID(), QuestID: questID}
+		c.questRecords = append(c.questRecords, record)
+	}
+
+	record.State = byte(state)
+	record.Progress = progress
+	if state == models.QuestStateComplete {
+		now := time.Now()
+		record.CompletedAt = &now
+	} else {
+		record.CompletedAt = nil
+	}
+	return record
+}
+
+// RemoveQuestRecord drops the record for a quest
+func (c *Character) RemoveQuestRecord(questID uint16) {
+	c.posMu.Lock()
+	defer c.posMu.Unlock()
+
+	for i, qr := range c.questRecords {
+		if qr.QuestID == questID {
+			c.questRecords = append(c.questRecords[:i], c.questRecords[i+1:]...)
+			return
+		}
+	}
+}
+
 // GetQuestState returns the state of a specific quest
 func (c *Character) GetQuestState(questID uint16) models.QuestState {
 	c.posMu.RLock()
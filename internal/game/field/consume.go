@@ -0,0 +1,54 @@
+package field
+
+import (
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers/item"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+)
+
+// cureSpecs maps consumable cure flags to the debuffs they remove
+var cureSpecs = map[item.ItemSpecsKey]packets.CharacterTemporaryStat{
+	item.KeySpecPoison:   packets.CTSPoison,
+	item.KeySpecSeal:     packets.CTSSeal,
+	item.KeySpecDarkness: packets.CTSDarkness,
+	item.KeySpecWeakness: packets.CTSWeakness,
+	item.KeySpecCurse:    packets.CTSCurse,
+}
+
+// Heal restores HP and MP, capped at their calculated maximums, and returns the changed stats
+func (c *Character) Heal(hp, mp int32) map[int32]int64 {
+	m := c.model
+	calc := c.CalcStats()
+	stats := make(map[int32]int64)
+	if hp > 0 && m.HP < calc.MaxHP {
+		m.HP = min(m.HP+hp, calc.MaxHP)
+		stats[packets.StatHP] = int64(m.HP)
+	}
+	if mp > 0 && m.MP < calc.MaxMP {
+		m.MP = min(m.MP+mp, calc.MaxMP)
+		stats[packets.StatMP] = int64(m.MP)
+	}
+	if _, ok := stats[packets.StatHP]; ok {
+		c.BroadcastPartyHP()
+	}
+	return stats
+}
+
+// ApplyConsumeItem applies a consumable's heal, cure and buff effects and
+// returns the changed stats
+func (c *Character) ApplyConsumeItem(info *item.ItemInfo) map[int32]int64 {
+	calc := c.CalcStats()
+	hp := info.GetSpecOr(item.KeySpecHP, 0) + calc.MaxHP*info.GetSpecOr(item.KeySpecHPR, 0)/100
+	mp := info.GetSpecOr(item.KeySpecMP, 0) + calc.MaxMP*info.GetSpecOr(item.KeySpecMPR, 0)/100
+	stats := c.Heal(hp, mp)
+
+	var cured []packets.CharacterTemporaryStat
+	for key, stat := range cureSpecs {
+		if info.GetSpecFlag(key) {
+			cured = append(cured, stat)
+		}
+	}
+	c.resetTemporaryStats(cured)
+
+	c.ApplyItemBuff(info)
+	return stats
+}
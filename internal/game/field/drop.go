@@ -0,0 +1,214 @@
+package field
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/consts"
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+)
+
+// DropOwnType controls who may pick up a drop before it becomes free for all
+type DropOwnType byte
+
+const (
+	DropOwnTypeUser       DropOwnType = 0
+	DropOwnTypeParty      DropOwnType = 1
+	DropOwnTypeFreeForAll DropOwnType = 2
+	DropOwnTypeExplosive  DropOwnType = 3 // Free for all, scattered on boss deaths
+)
+
+// Drop is an item or meso bag lying on the field
+type Drop struct {
+	objectID int32
+	item     *models.CharacterItem // nil for meso drops
+	mesos    int32
+
+	ownerID uint
+	partyID uint
+	ownType DropOwnType
+	questID int32 // Only visible and pickable while this quest is in progress
+
+	x       uint16
+	y       uint16
+	sourceX uint16 // Where the drop animation starts
+	sourceY uint16
+
+	sourceID   int32 // Object ID of the mob that dropped it, 0 for player drops
+	playerDrop bool
+	createdAt  time.Time
+
+	mu      sync.Mutex
+	claimed bool
+}
+
+// NewItemDrop creates a drop holding an item
+func NewItemDrop(objectID int32, item *models.CharacterItem, x, y uint16) *Drop {
+	return &Drop{
+		objectID:  objectID,
+		item:      item,
+		x:         x,
+		y:         y,
+		sourceX:   x,
+		sourceY:   y,
+		ownType:   DropOwnTypeFreeForAll,
+		createdAt: time.Now(),
+	}
+}
+
+// NewMesoDrop creates a drop holding mesos
+func NewMesoDrop(objectID int32, mesos int32, x, y uint16) *Drop {
+	return &Drop{
+		objectID:  objectID,
+		mesos:     mesos,
+		x:         x,
+		y:         y,
+		sourceX:   x,
+		sourceY:   y,
+		ownType:   DropOwnTypeFreeForAll,
+		createdAt: time.Now(),
+	}
+}
+
+// SetOwner restricts pickup to a character (and their party, for DropOwnTypeParty)
+func (d *Drop) SetOwner(ownerID, partyID uint, ownType DropOwnType) {
+	d.ownerID = ownerID
+	d.partyID = partyID
+	d.ownType = ownType
+}
+
+// SetSource sets what dropped the item and where its animation starts
+func (d *Drop) SetSource(sourceID int32, x, y uint16, playerDrop bool) {
+	d.sourceID = sourceID
+	d.sourceX = x
+	d.sourceY = y
+	d.playerDrop = playerDrop
+}
+
+// SetQuest makes the drop a quest item for the given quest
+func (d *Drop) SetQuest(questID int32) {
+	d.questID = questID
+}
+
+// ObjectID returns the drop's object ID
+func (d *Drop) ObjectID() int32 {
+	return d.objectID
+}
+
+// Item returns the dropped item, or nil for meso drops
+func (d *Drop) Item() *models.CharacterItem {
+	return d.item
+}
+
+// Mesos returns the amount of mesos in a meso drop
+func (d *Drop) Mesos() int32 {
+	return d.mesos
+}
+
+// IsMoney returns true for meso drops
+func (d *Drop) IsMoney() bool {
+	return d.item == nil
+}
+
+// Info returns the meso amount or item ID shown by the client
+func (d *Drop) Info() int32 {
+	if d.item == nil {
+		return d.mesos
+	}
+	return d.item.ItemID
+}
+
+// OwnerID returns the ID of the character or party that owns the drop
+func (d *Drop) OwnerID() uint {
+	if d.ownType == DropOwnTypeParty {
+		return d.partyID
+	}
+	return d.ownerID
+}
+
+// OwnType returns the drop's ownership type
+func (d *Drop) OwnType() byte {
+	return byte(d.ownType)
+}
+
+// QuestID returns the quest the drop belongs to, or 0
+func (d *Drop) QuestID() int32 {
+	return d.questID
+}
+
+// Position returns where the drop lies
+func (d *Drop) Position() (x, y uint16) {
+	return d.x, d.y
+}
+
+// SourceID returns the object ID of the mob that dropped it
+func (d *Drop) SourceID() int32 {
+	return d.sourceID
+}
+
+// SourcePosition returns where the drop animation starts
+func (d *Drop) SourcePosition() (x, y uint16) {
+	return d.sourceX, d.sourceY
+}
+
+// IsPlayerDrop returns true if a character dropped the item from their inventory
+func (d *Drop) IsPlayerDrop() bool {
+	return d.playerDrop
+}
+
+// ItemExpireAt returns the expiry of the dropped item, or nil
+func (d *Drop) ItemExpireAt() *time.Time {
+	if d.item == nil {
+		return nil
+	}
+	return d.item.ExpireAt
+}
+
+// IsExpired returns true once the drop has been on the ground too long
+func (d *Drop) IsExpired(now time.Time) bool {
+	return now.Sub(d.createdAt) >= consts.DropExpireTime
+}
+
+// IsVisibleTo returns false for quest drops the character isn't collecting
+func (d *Drop) IsVisibleTo(char *Character) bool {
+	return d.questID == 0 || char.GetQuestState(uint16(d.questID)) == models.QuestStatePerform
+}
+
+// CanPickUp checks the ownership rules: owners (or their party) get the drop first,
+// anyone may take it once the ownership time runs out
+func (d *Drop) CanPickUp(char *Character, now time.Time) bool {
+	if !d.IsVisibleTo(char) {
+		return false
+	}
+
+	switch d.ownType {
+	case DropOwnTypeFreeForAll, DropOwnTypeExplosive:
+		return true
+	}
+	if now.Sub(d.createdAt) >= consts.DropOwnershipTime {
+		return true
+	}
+	if char.ID() == d.ownerID {
+		return true
+	}
+	return d.ownType == DropOwnTypeParty && d.partyID != 0 && char.PartyID() == d.partyID
+}
+
+// Claim marks the drop as taken. Only the first caller gets true, so two
+// characters can't pick up the same drop.
+func (d *Drop) Claim() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.claimed {
+		return false
+	}
+	d.claimed = true
+	return true
+}
+
+// Release undoes a claim when the pickup could not be completed
+func (d *Drop) Release() {
+	d.mu.Lock()
+	d.claimed = false
+	d.mu.Unlock()
+}
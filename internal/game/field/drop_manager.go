@@ -0,0 +1,84 @@
+package field
+
+import (
+	"sync"
+	"time"
+)
+
+// DropManager manages all drops within a field.
+// Thread-safe for concurrent access.
+type DropManager struct {
+	drops map[int32]*Drop // objectID -> Drop
+	mu    sync.RWMutex
+}
+
+// NewDropManager creates a new drop manager
+func NewDropManager() *DropManager {
+	return &DropManager{
+		drops: make(map[int32]*Drop),
+	}
+}
+
+// Add adds a drop to the manager
+func (m *DropManager) Add(drop *Drop) {
+	if drop == nil {
+		return
+	}
+
+	m.mu.Lock()
+	m.drops[drop.objectID] = drop
+	m.mu.Unlock()
+}
+
+// Remove removes a drop by object ID, returning false if it was already gone
+func (m *DropManager) Remove(objectID int32) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.drops[objectID]; !ok {
+		return false
+	}
+	delete(m.drops, objectID)
+	return true
+}
+
+// Get returns a drop by object ID, or nil if not found
+func (m *DropManager) Get(objectID int32) *Drop {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.drops[objectID]
+}
+
+// GetAll returns all drops (returns a copy for thread safety)
+func (m *DropManager) GetAll() []*Drop {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	drops := make([]*Drop, 0, len(m.drops))
+	for _, drop := range m.drops {
+		drops = append(drops, drop)
+	}
+	return drops
+}
+
+// RemoveExpired removes and returns every drop that has expired
+func (m *DropManager) RemoveExpired(now time.Time) []*Drop {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expired []*Drop
+	for id, drop := range m.drops {
+		if drop.IsExpired(now) {
+			expired = append(expired, drop)
+			delete(m.drops, id)
+		}
+	}
+	return expired
+}
+
+// Count returns the number of drops
+func (m *DropManager) Count() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.drops)
+}
@@ -13,28 +13,35 @@ import (
 
 type Field struct {
 	mapData      *providers.MapData
+	mobProvider  MobTemplateProvider
 	nextObjectID int32
 	characters   *CharacterManager
 	npcs         *NPCManager
 	mobs         *MobManager
+	drops        *DropManager
 	mu           sync.RWMutex
 
+	mobSpawns []*mobSpawnPoint
+	spawnMu   sync.Mutex
+
 	stop      chan struct{}
 	startOnce sync.Once
 	closeOnce sync.Once
 }
 
-func NewField(mapData *providers.MapData) *Field {
+func NewField(mapData *providers.MapData, mobProvider MobTemplateProvider) *Field {
 	if mapData == nil {
 		panic("field.NewField: mapData is nil")
 	}
 
 	f := &Field{
 		mapData:      mapData,
+		mobProvider:  mobProvider,
 		nextObjectID: 1000,
 		characters:   NewCharacterManager(),
 		npcs:         NewNPCManager(),
 		mobs:         NewMobManager(),
+		drops:        NewDropManager(),
 		stop:         make(chan struct{}),
 	}
 
@@ -55,12 +62,12 @@ func (f *Field) spawnLife() {
 		f.npcs.Add(npc)
 	}
 
-	// Spawn mobs
+	// Spawn mobs, one per spawn point
 	for i := range f.mapData.MobSpawns {
 		spawn := &f.mapData.MobSpawns[i]
-		objectID := f.NextObjectID()
-		mob := NewMob(objectID, spawn)
+		mob := f.newMob(spawn)
 		f.mobs.Add(mob)
+		f.mobSpawns = append(f.mobSpawns, &mobSpawnPoint{spawn: spawn, objectIDs: []int32{mob.ObjectID()}})
 	}
 
 	npcCount := f.npcs.Count()
@@ -112,8 +119,10 @@ func (f *Field) tickLoop() {
 }
 
 func (f *Field) Tick() {
-	_ = time.Now()
-	// TODO: Update mobs, handle respawns, process movement, etc.
+	now := time.Now()
+	f.respawnMobs(now)
+	f.expireDrops(now)
+	f.expireTemporaryStats(now)
 }
 
 // ID returns the map ID.
@@ -159,6 +168,7 @@ func (f *Field) RemoveCharacter(c *Character) {
 	}
 
 	f.characters.Remove(c.ID())
+	f.reassignControllers(c)
 	log.Printf("[Field %d] Removed character %s (ID: %d)", f.mapData.ID, c.Name(), c.ID())
 }
 
@@ -260,15 +270,87 @@ func (f *Field) AliveMobCount() int {
 	return f.mobs.AliveCount()
 }
 
-// RemoveMob removes a mob from the field.
+// RemoveMob removes a mob from the field and schedules its spawn point to respawn.
 func (f *Field) RemoveMob(objectID int32) {
 	f.mobs.Remove(objectID)
+	f.releaseSpawnPoint(objectID, time.Now())
 }
 
-// AssignControllerToMobs assigns a character as controller to all mobs
+// AssignControllerToMobs gives a character control of every mob that has no controller
 func (f *Field) AssignControllerToMobs(char *Character) {
 	for _, mob := range f.GetAliveMobs() {
+		if mob.HasController() {
+			continue
+		}
 		mob.AssignController(char)
-		// TODO: Send MobChangeController packet
+		char.Write(packets.MobChangeController(mob, 1, true))
+	}
+}
+
+// reassignControllers hands the life objects a departing character controlled to someone else
+func (f *Field) reassignControllers(leaving *Character) {
+	for _, mob := range f.GetAliveMobs() {
+		if mob.Controller() == leaving {
+			f.assignMobController(mob)
+		}
+	}
+
+	for _, npc := range f.GetAllNPCs() {
+		if npc.Controller() != leaving {
+			continue
+		}
+		chars := f.GetAllCharacters()
+		if len(chars) == 0 {
+			npc.AssignController(nil)
+			continue
+		}
+		npc.AssignController(chars[0])
+		chars[0].Write(packets.NpcChangeController(npc, true, false))
+	}
+}
+
+// AddDrop puts a drop on the field and shows it to the characters that can see it.
+// delay is how long the client waits before animating the drop.
+func (f *Field) AddDrop(drop *Drop, delay int16) {
+	f.drops.Add(drop)
+
+	p := packets.DropEnterField(drop, packets.DropEnterCreate, delay)
+	for _, char := range f.GetAllCharacters() {
+		if drop.IsVisibleTo(char) {
+			char.Write(p)
+		}
+	}
+}
+
+// GetDrop returns a drop by object ID, or nil if not found.
+func (f *Field) GetDrop(objectID int32) *Drop {
+	return f.drops.Get(objectID)
+}
+
+// GetVisibleDrops returns the drops a character is able to see.
+func (f *Field) GetVisibleDrops(char *Character) []*Drop {
+	var visible []*Drop
+	for _, drop := range f.drops.GetAll() {
+		if drop.IsVisibleTo(char) {
+			visible = append(visible, drop)
+		}
+	}
+	return visible
+}
+
+// RemoveDrop takes a drop off the field. pickerID is the character that picked it up,
+// for pickup leave types. Returns false if the drop was already gone.
+func (f *Field) RemoveDrop(objectID int32, leaveType byte, pickerID uint) bool {
+	if !f.drops.Remove(objectID) {
+		return false
+	}
+	f.Broadcast(packets.DropLeaveField(objectID, leaveType, int32(pickerID)))
+	return true
+}
+
+// expireDrops removes drops that have been on the ground too long
+func (f *Field) expireDrops(now time.Time) {
+	for _, drop := range f.drops.RemoveExpired(now) {
+		f.Broadcast(packets.DropLeaveField(drop.ObjectID(), packets.DropLeaveTimeout, 0))
 	}
 }
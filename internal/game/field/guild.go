@@ -0,0 +1,56 @@
+package field
+
+import "github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+
+// GuildID returns the ID of the character's guild, or 0 if not in one
+func (c *Character) GuildID() uint {
+	if c.model == nil || c.model.GuildID == nil {
+		return 0
+	}
+	return *c.model.GuildID
+}
+
+// GuildGrade returns the character's rank in their guild
+func (c *Character) GuildGrade() byte {
+	if c.model == nil {
+		return 0
+	}
+	return c.model.GuildGrade
+}
+
+// SetGuild sets the character's guild and rank, or clears both when guildID is 0
+func (c *Character) SetGuild(guildID uint, grade byte) {
+	if c.model == nil {
+		return
+	}
+	if guildID == 0 {
+		c.model.GuildID = nil
+		c.model.GuildGrade = 0
+		c.SetGuildInfo("", packets.GuildMark{})
+		return
+	}
+	c.model.GuildID = &guildID
+	c.model.GuildGrade = grade
+}
+
+// GuildName returns the guild name shown over the character's head
+func (c *Character) GuildName() string {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+	return c.guildName
+}
+
+// GuildMark returns the guild emblem shown over the character's head
+func (c *Character) GuildMark() packets.GuildMark {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+	return c.guildMark
+}
+
+// SetGuildInfo sets the guild name and emblem shown over the character's head
+func (c *Character) SetGuildInfo(name string, mark packets.GuildMark) {
+	c.posMu.Lock()
+	defer c.posMu.Unlock()
+	c.guildName = name
+	c.guildMark = mark
+}
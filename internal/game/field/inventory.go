@@ -0,0 +1,651 @@
+package field
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers/item"
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/utils"
+)
+
+const (
+	// DefaultSlotCount is the number of slots in each inventory tab
+	DefaultSlotCount int16 = 24
+
+	defaultSlotMax int16 = 100
+)
+
+// SlotCount returns the number of usable slots in an inventory tab
+func (c *Character) SlotCount(invType models.InventoryType) int16 {
+	return DefaultSlotCount
+}
+
+// SlotMax returns how many of an item fit in a single inventory slot
+func (c *Character) SlotMax(itemID int32) int16 {
+	if utils.GetItemTypeByItemID(itemID) != utils.ItemTypeBundle {
+		return 1
+	}
+	if c.itemProvider != nil {
+		if info := c.itemProvider.GetItemInfo(itemID); info != nil {
+			if slotMax := info.GetInfoOr(item.KeySlotMax, int32(defaultSlotMax)); slotMax > 0 {
+				return int16(slotMax)
+			}
+		}
+	}
+	return defaultSlotMax
+}
+
+// ItemAt returns the item in an inventory slot, or nil if the slot is empty
+func (c *Character) ItemAt(invType models.InventoryType, slot int16) *models.CharacterItem {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+	return c.itemAtLocked(invType, slot)
+}
+
+// FreeSlotCount returns the number of empty slots in an inventory tab
+func (c *Character) FreeSlotCount(invType models.InventoryType) int {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+	return len(c.freeSlotsLocked(invType))
+}
+
+// CanHold checks whether count of an item would fit in the inventory
+func (c *Character) CanHold(itemID int32, count int16) bool {
+	invType := utils.GetInventoryTypeByItemID(itemID)
+	if invType == 0 {
+		return false
+	}
+
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+	_, needed := c.planGainLocked(itemID, count, invType)
+	return needed <= len(c.freeSlotsLocked(invType))
+}
+
+// CanHoldAll checks whether all the items, given as counts by item ID, would fit
+// in the inventory together
+func (c *Character) CanHoldAll(items map[int32]int16) bool {
+	needed := make(map[models.InventoryType]int)
+
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+	for itemID, count := range items {
+		invType := utils.GetInventoryTypeByItemID(itemID)
+		if invType == 0 {
+			return false
+		}
+		_, n := c.planGainLocked(itemID, count, invType)
+		needed[invType] += n
+	}
+	for invType, n := range needed {
+		if n > len(c.freeSlotsLocked(invType)) {
+			return false
+		}
+	}
+	return true
+}
+
+// GainItem gives count of an item to the character and notifies the client.
+// Returns false without touching the inventory if there is not enough space.
+func (c *Character) GainItem(itemID int32, count int16) bool {
+	if count < 0 {
+		return c.RemoveItem(itemID, -count)
+	}
+	if count == 0 {
+		return true
+	}
+
+	invType := utils.GetInventoryTypeByItemID(itemID)
+	if invType == 0 {
+		log.Printf("[Inventory] %s: unknown inventory for item %d", c.Name(), itemID)
+		return false
+	}
+
+	c.posMu.Lock()
+	ops, ok := c.gainItemLocked(itemID, count, invType)
+	c.posMu.Unlock()
+
+	if !ok {
+		log.Printf("[Inventory] %s: no space for item %d x%d", c.Name(), itemID, count)
+		return false
+	}
+
+	c.Write(packets.InventoryOperationPacket(true, ops))
+	return true
+}
+
+// RemoveItem takes count of an item from the character's inventory and notifies the client.
+// Returns false without touching the inventory if the character doesn't have enough.
+func (c *Character) RemoveItem(itemID int32, count int16) bool {
+	if count < 0 {
+		return c.GainItem(itemID, -count)
+	}
+	if count == 0 {
+		return true
+	}
+
+	invType := utils.GetInventoryTypeByItemID(itemID)
+	if invType == 0 {
+		return false
+	}
+
+	c.posMu.Lock()
+	ops, ok := c.removeItemLocked(itemID, count, invType)
+	c.posMu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	c.Write(packets.InventoryOperationPacket(true, ops))
+	return true
+}
+
+// AddItem puts an existing item instance, such as a picked up drop, into the inventory
+// and notifies the client. Stackable items are merged into existing stacks with the
+// same expiry and owner, and the rest keeps its own slot.
+// Returns false without touching the inventory if there is not enough space.
+func (c *Character) AddItem(it *models.CharacterItem) bool {
+	invType := utils.GetInventoryTypeByItemID(it.ItemID)
+	if invType == 0 || it.Quantity <= 0 {
+		return false
+	}
+
+	var ops []packets.InventoryOperation
+	c.posMu.Lock()
+	merges, remaining := map[*models.CharacterItem]int16{}, it.Quantity
+	if c.isStackable(it.ItemID) {
+		merges, remaining = c.planMergeLocked(it, it.Quantity, invType)
+	}
+	free := c.freeSlotsLocked(invType)
+	if remaining > 0 && len(free) == 0 {
+		c.posMu.Unlock()
+		return false
+	}
+
+	for _, stack := range c.sortedItemsLocked(invType) {
+		if n, ok := merges[stack]; ok {
+			stack.Quantity += n
+			ops = append(ops, packets.InventoryOperation{
+				Type:     packets.InventoryOpQuantity,
+				InvType:  invType,
+				Slot:     stack.Slot,
+				Quantity: stack.Quantity,
+			})
+		}
+	}
+	if remaining > 0 {
+		it.Quantity = remaining
+		it.InvType = invType
+		it.Slot = free[0]
+		it.CharacterID = c.ID()
+		if c.model != nil {
+			c.model.ItemSNCounter++
+			it.ItemSN = int64(c.model.ItemSNCounter)
+		}
+		c.items = append(c.items, it)
+		ops = append(ops, packets.InventoryOperation{Type: packets.InventoryOpAdd, InvType: invType, Slot: it.Slot, Item: it})
+	}
+	c.posMu.Unlock()
+
+	c.Write(packets.InventoryOperationPacket(true, ops))
+	return true
+}
+
+// TakeItem removes count of the item in a slot and returns it as a standalone item,
+// e.g. to drop it on the field. Part of a stack is split off into a new item.
+func (c *Character) TakeItem(invType models.InventoryType, slot int16, count int16) (*models.CharacterItem, error) {
+	if slot <= 0 || invType == models.InvEquipped {
+		return nil, fmt.Errorf("cannot take item from slot %d", slot)
+	}
+
+	c.posMu.Lock()
+	it := c.itemAtLocked(invType, slot)
+	if it == nil {
+		c.posMu.Unlock()
+		return nil, fmt.Errorf("no item in slot %d", slot)
+	}
+	if count <= 0 || count > it.Quantity {
+		c.posMu.Unlock()
+		return nil, fmt.Errorf("invalid count %d for item %d x%d", count, it.ItemID, it.Quantity)
+	}
+
+	var taken *models.CharacterItem
+	var op packets.InventoryOperation
+	if count == it.Quantity {
+		c.deleteItemLocked(it)
+		taken = it
+		op = packets.InventoryOperation{Type: packets.InventoryOpRemove, InvType: invType, Slot: slot}
+	} else {
+		it.Quantity -= count
+		split := *it
+		split.Quantity = count
+		taken = &split
+		op = packets.InventoryOperation{Type: packets.InventoryOpQuantity, InvType: invType, Slot: slot, Quantity: it.Quantity}
+	}
+	c.posMu.Unlock()
+
+	// The item no longer belongs to anyone, whoever picks it up saves it as a new row
+	taken.ID = 0
+	taken.CharacterID = 0
+	taken.ItemSN = 0
+	taken.Slot = 0
+
+	c.Write(packets.InventoryOperationPacket(true, []packets.InventoryOperation{op}))
+	return taken, nil
+}
+
+// planGainLocked works out how much of count can be merged into existing stacks
+// and how many new slots are needed for the rest
+func (c *Character) planGainLocked(itemID int32, count int16, invType models.InventoryType) (map[*models.CharacterItem]int16, int) {
+	slotMax := c.SlotMax(itemID)
+	merges := make(map[*models.CharacterItem]int16)
+	remaining := count
+
+	// Rechargeables keep their own stacks, everything else bundled is merged first
+	if c.isStackable(itemID) {
+		merges, remaining = c.planMergeLocked(&models.CharacterItem{ItemID: itemID}, count, invType)
+	}
+
+	needed := int((remaining + slotMax - 1) / slotMax)
+	return merges, needed
+}
+
+// planMergeLocked works out how much of count of an item can be merged into existing
+// stacks it may share, returning the merges and what is left over
+func (c *Character) planMergeLocked(like *models.CharacterItem, count int16, invType models.InventoryType) (map[*models.CharacterItem]int16, int16) {
+	slotMax := c.SlotMax(like.ItemID)
+	merges := make(map[*models.CharacterItem]int16)
+	remaining := count
+
+	for _, it := range c.sortedItemsLocked(invType) {
+		if remaining == 0 {
+			break
+		}
+		if it.Quantity >= slotMax || !sameStack(it, like) {
+			continue
+		}
+		n := min(slotMax-it.Quantity, remaining)
+		merges[it] = n
+		remaining -= n
+	}
+	return merges, remaining
+}
+
+// sameStack reports whether two items may share a stack. Items that expire or carry
+// an owner's name only stack with ones that match.
+func sameStack(a, b *models.CharacterItem) bool {
+	if a.ItemID != b.ItemID || a.Owner != b.Owner {
+		return false
+	}
+	if a.ExpireAt == nil || b.ExpireAt == nil {
+		return a.ExpireAt == b.ExpireAt
+	}
+	return a.ExpireAt.Equal(*b.ExpireAt)
+}
+
+func (c *Character) gainItemLocked(itemID int32, count int16, invType models.InventoryType) ([]packets.InventoryOperation, bool) {
+	merges, needed := c.planGainLocked(itemID, count, invType)
+	free := c.freeSlotsLocked(invType)
+	if needed > len(free) {
+		return nil, false
+	}
+
+	var ops []packets.InventoryOperation
+	remaining := count
+
+	for _, it := range c.sortedItemsLocked(invType) {
+		n, ok := merges[it]
+		if !ok {
+			continue
+		}
+		it.Quantity += n
+		remaining -= n
+		ops = append(ops, packets.InventoryOperation{
+			Type:     packets.InventoryOpQuantity,
+			InvType:  invType,
+			Slot:     it.Slot,
+			Quantity: it.Quantity,
+		})
+	}
+
+	slotMax := c.SlotMax(itemID)
+	for i := 0; remaining > 0; i++ {
+		n := min(slotMax, remaining)
+		it := c.newItemLocked(itemID, invType, free[i], n)
+		c.items = append(c.items, it)
+		remaining -= n
+		ops = append(ops, packets.InventoryOperation{
+			Type:    packets.InventoryOpAdd,
+			InvType: invType,
+			Slot:    it.Slot,
+			Item:    it,
+		})
+	}
+
+	return ops, true
+}
+
+func (c *Character) removeItemLocked(itemID int32, count int16, invType models.InventoryType) ([]packets.InventoryOperation, bool) {
+	var stacks []*models.CharacterItem
+	var total int32
+	for _, it := range c.sortedItemsLocked(invType) {
+		if it.ItemID == itemID {
+			stacks = append(stacks, it)
+			total += int32(it.Quantity)
+		}
+	}
+	if total < int32(count) {
+		return nil, false
+	}
+
+	var ops []packets.InventoryOperation
+	remaining := count
+	for _, it := range stacks {
+		if remaining == 0 {
+			break
+		}
+		if it.Quantity <= remaining {
+			remaining -= it.Quantity
+			c.deleteItemLocked(it)
+			ops = append(ops, packets.InventoryOperation{
+				Type:    packets.InventoryOpRemove,
+				InvType: invType,
+				Slot:    it.Slot,
+			})
+			continue
+		}
+		it.Quantity -= remaining
+		remaining = 0
+		ops = append(ops, packets.InventoryOperation{
+			Type:     packets.InventoryOpQuantity,
+			InvType:  invType,
+			Slot:     it.Slot,
+			Quantity: it.Quantity,
+		})
+	}
+
+	return ops, true
+}
+
+// newItemLocked creates a new item instance, pulling equip stats from the item provider
+func (c *Character) newItemLocked(itemID int32, invType models.InventoryType, slot int16, quantity int16) *models.CharacterItem {
+	var it *models.CharacterItem
+	if invType == models.InvEquip && c.itemProvider != nil {
+		it = utils.NewEquipFromItemInfo(c.itemProvider.GetItemInfo(itemID), invType, slot)
+	}
+	if it == nil {
+		it = &models.CharacterItem{
+			InvType:  invType,
+			Slot:     slot,
+			ItemID:   itemID,
+			Quantity: quantity,
+		}
+	}
+
+	it.CharacterID = c.ID()
+	if c.model != nil {
+		c.model.ItemSNCounter++
+		it.ItemSN = int64(c.model.ItemSNCounter)
+	}
+	return it
+}
+
+func (c *Character) itemAtLocked(invType models.InventoryType, slot int16) *models.CharacterItem {
+	for _, it := range c.items {
+		if it.InvType == invType && it.Slot == slot {
+			return it
+		}
+	}
+	return nil
+}
+
+// sortedItemsLocked returns the items in an inventory tab ordered by slot
+func (c *Character) sortedItemsLocked(invType models.InventoryType) []*models.CharacterItem {
+	var items []*models.CharacterItem
+	for _, it := range c.items {
+		if it.InvType == invType {
+			items = append(items, it)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Slot < items[j].Slot })
+	return items
+}
+
+// freeSlotsLocked returns the empty slots of an inventory tab in ascending order
+func (c *Character) freeSlotsLocked(invType models.InventoryType) []int16 {
+	used := make(map[int16]bool)
+	for _, it := range c.items {
+		if it.InvType == invType {
+			used[it.Slot] = true
+		}
+	}
+
+	var free []int16
+	for slot := int16(1); slot <= c.SlotCount(invType); slot++ {
+		if !used[slot] {
+			free = append(free, slot)
+		}
+	}
+	return free
+}
+
+func (c *Character) deleteItemLocked(target *models.CharacterItem) {
+	for i, it := range c.items {
+		if it == target {
+			c.items = append(c.items[:i], c.items[i+1:]...)
+			return
+		}
+	}
+}
+
+// Equipped returns the items the character is currently wearing
+func (c *Character) Equipped() []*models.CharacterItem {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+	return c.sortedItemsLocked(models.InvEquipped)
+}
+
+// ChangeSlot handles a client item move within an inventory tab: swapping, merging and
+// splitting stacks, and equipping or unequipping gear. The resulting InventoryOperation is
+// sent to the client on success.
+func (c *Character) ChangeSlot(invType models.InventoryType, from, to int16, count int16) error {
+	if from == to || to == 0 {
+		return fmt.Errorf("invalid move %d -> %d", from, to)
+	}
+	if (from < 0 || to < 0) && invType != models.InvEquip {
+		return fmt.Errorf("equipped slot used outside the equip inventory")
+	}
+
+	c.posMu.Lock()
+	ops, err := c.changeSlotLocked(invType, from, to, count)
+	c.posMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	c.Write(packets.InventoryOperationPacket(true, ops))
+	if from < 0 || to < 0 {
+		c.RecalcStats()
+	}
+	return nil
+}
+
+func (c *Character) changeSlotLocked(invType models.InventoryType, from, to int16, count int16) ([]packets.InventoryOperation, error) {
+	src := c.itemAtLocked(slotInventoryType(invType, from), from)
+	if src == nil {
+		return nil, fmt.Errorf("no item in slot %d", from)
+	}
+	if to > 0 && to > c.SlotCount(invType) {
+		return nil, fmt.Errorf("slot %d out of range", to)
+	}
+	dst := c.itemAtLocked(slotInventoryType(invType, to), to)
+
+	if from < 0 || to < 0 {
+		return c.moveEquipLocked(src, dst, from, to)
+	}
+
+	// Merge into a matching stack
+	if dst != nil && sameStack(dst, src) && c.isStackable(src.ItemID) {
+		slotMax := c.SlotMax(src.ItemID)
+		if dst.Quantity < slotMax {
+			n := min(slotMax-dst.Quantity, src.Quantity)
+			dst.Quantity += n
+			src.Quantity -= n
+
+			ops := []packets.InventoryOperation{{
+				Type: packets.InventoryOpQuantity, InvType: invType, Slot: to, Quantity: dst.Quantity,
+			}}
+			if src.Quantity == 0 {
+				c.deleteItemLocked(src)
+				ops = append(ops, packets.InventoryOperation{Type: packets.InventoryOpRemove, InvType: invType, Slot: from})
+			} else {
+				ops = append(ops, packets.InventoryOperation{
+					Type: packets.InventoryOpQuantity, InvType: invType, Slot: from, Quantity: src.Quantity,
+				})
+			}
+			return ops, nil
+		}
+	}
+
+	// Split part of a stack into an empty slot
+	if dst == nil && count > 0 && count < src.Quantity && c.isStackable(src.ItemID) {
+		src.Quantity -= count
+		split := c.newItemLocked(src.ItemID, invType, to, count)
+		split.ExpireAt = src.ExpireAt
+		split.Owner = src.Owner
+		split.Attribute = src.Attribute
+		c.items = append(c.items, split)
+
+		return []packets.InventoryOperation{
+			{Type: packets.InventoryOpQuantity, InvType: invType, Slot: from, Quantity: src.Quantity},
+			{Type: packets.InventoryOpAdd, InvType: invType, Slot: to, Item: split},
+		}, nil
+	}
+
+	// Plain move or swap
+	src.Slot = to
+	if dst != nil {
+		dst.Slot = from
+	}
+	return []packets.InventoryOperation{{Type: packets.InventoryOpMove, InvType: invType, Slot: from, NewSlot: to}}, nil
+}
+
+// moveEquipLocked swaps an item into or out of an equipped slot
+func (c *Character) moveEquipLocked(src, dst *models.CharacterItem, from, to int16) ([]packets.InventoryOperation, error) {
+	if to < 0 {
+		if err := c.checkEquipLocked(src, to); err != nil {
+			return nil, err
+		}
+	}
+	if from < 0 && dst != nil {
+		if err := c.checkEquipLocked(dst, from); err != nil {
+			return nil, err
+		}
+	}
+
+	var ops []packets.InventoryOperation
+
+	// An overall takes the place of both top and pants
+	if to < 0 {
+		var displaced *models.CharacterItem
+		if to == -int16(item.BodyPartClothes) && utils.IsOverall(src.ItemID) {
+			displaced = c.itemAtLocked(models.InvEquipped, -int16(item.BodyPartPants))
+		} else if to == -int16(item.BodyPartPants) {
+			if top := c.itemAtLocked(models.InvEquipped, -int16(item.BodyPartClothes)); top != nil && utils.IsOverall(top.ItemID) {
+				displaced = top
+			}
+		}
+
+		if displaced != nil {
+			free := c.freeSlotsLocked(models.InvEquip)
+			if dst == nil && from > 0 {
+				// The source slot frees up once src is equipped
+				free = append([]int16{from}, free...)
+			}
+			if len(free) == 0 {
+				return nil, fmt.Errorf("no free slot to unequip item %d", displaced.ItemID)
+			}
+			ops = append(ops, packets.InventoryOperation{
+				Type: packets.InventoryOpMove, InvType: models.InvEquip, Slot: displaced.Slot, NewSlot: free[0],
+			})
+			displaced.InvType = models.InvEquip
+			displaced.Slot = free[0]
+		}
+	}
+
+	src.InvType = slotInventoryType(models.InvEquip, to)
+	src.Slot = to
+	if dst != nil {
+		dst.InvType = slotInventoryType(models.InvEquip, from)
+		dst.Slot = from
+	}
+
+	// The displaced move must come after the equip so the client sees the freed slot
+	ops = append([]packets.InventoryOperation{{
+		Type: packets.InventoryOpMove, InvType: models.InvEquip, Slot: from, NewSlot: to,
+	}}, ops...)
+	return ops, nil
+}
+
+// checkEquipLocked validates that an item fits an equipped slot and the character meets its requirements
+func (c *Character) checkEquipLocked(it *models.CharacterItem, slot int16) error {
+	if !utils.IsValidEquipSlot(it.ItemID, slot, it.Cash) {
+		return fmt.Errorf("item %d cannot be equipped in slot %d", it.ItemID, slot)
+	}
+	if c.model == nil || c.itemProvider == nil {
+		return nil
+	}
+
+	info := c.itemProvider.GetItemInfo(it.ItemID)
+	if info == nil {
+		return nil
+	}
+
+	if int32(c.model.Level) < info.GetInfoOr(item.KeyReqLevel, 0) {
+		return fmt.Errorf("level too low for item %d", it.ItemID)
+	}
+	// Stat requirements are met with equips and buffs included, as the client checks them
+	stats := c.calcStats
+	if stats.STR < info.GetInfoOr(item.KeyReqSTR, 0) ||
+		stats.DEX < info.GetInfoOr(item.KeyReqDEX, 0) ||
+		stats.INT < info.GetInfoOr(item.KeyReqINT, 0) ||
+		stats.LUK < info.GetInfoOr(item.KeyReqLUK, 0) {
+		return fmt.Errorf("stats too low for item %d", it.ItemID)
+	}
+	if int32(c.model.Fame) < info.GetInfoOr(item.KeyReqPop, 0) {
+		return fmt.Errorf("fame too low for item %d", it.ItemID)
+	}
+	if !meetsJobRequirement(game.Job(c.model.Job), info.GetInfoOr(item.KeyReqJob, 0)) {
+		return fmt.Errorf("job %d cannot equip item %d", c.model.Job, it.ItemID)
+	}
+	return nil
+}
+
+// meetsJobRequirement checks a reqJob bitmask (1 warrior, 2 magician, 4 bowman, 8 thief, 16 pirate, -1 beginner only)
+func meetsJobRequirement(job game.Job, reqJob int32) bool {
+	switch {
+	case reqJob == 0:
+		return true
+	case reqJob < 0:
+		return job.Category() == 0
+	}
+	category := job.Category()
+	return category > 0 && reqJob&(1<<(category-1)) != 0
+}
+
+func (c *Character) isStackable(itemID int32) bool {
+	return utils.GetItemTypeByItemID(itemID) == utils.ItemTypeBundle && !utils.IsRechargeableItem(itemID)
+}
+
+// slotInventoryType returns the stored inventory for a slot, since equipped items use negative equip slots
+func slotInventoryType(invType models.InventoryType, slot int16) models.InventoryType {
+	if invType == models.InvEquip && slot < 0 {
+		return models.InvEquipped
+	}
+	return invType
+}
@@ -0,0 +1,125 @@
+package field
+
+import (
+	"math/rand/v2"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/game"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+)
+
+const (
+	// maxHPMP is the cap on a character's base max HP and MP
+	maxHPMP = 30000
+
+	levelUpAP       = 5
+	levelUpAPCygnus = 6 // Cygnus Knights get an extra AP per level until cygnusBonusAPLevel
+	levelUpSP       = 3
+
+	cygnusBonusAPLevel = 70
+	beginnerSPLevel    = 10 // Beginners get 1 SP per level up to this level
+)
+
+// GainEXP adds experience to the character, levelling up as many times as the EXP
+// covers until the job's max level, where EXP stops. The owner is sent the changed
+// stats and the field sees the level up effect.
+func (c *Character) GainEXP(exp int32) {
+	if c.model == nil {
+		return
+	}
+
+	total := int64(c.model.EXP) + int64(exp)
+	if total < 0 {
+		total = 0
+	}
+
+	maxLevel := game.Job(c.model.Job).MaxLevel()
+	stats := make(map[int32]int64)
+	levels := 0
+	for int(c.model.Level) < maxLevel {
+		need := int64(game.NextLevelEXP(int(c.model.Level)))
+		if total < need {
+			break
+		}
+		total -= need
+		c.levelUp(stats)
+		levels++
+	}
+	if int(c.model.Level) >= maxLevel {
+		total = 0
+	}
+
+	c.model.EXP = int32(total)
+	stats[packets.StatEXP] = total
+	c.Write(packets.StatChanged(false, stats))
+
+	if levels > 0 {
+		c.RecalcStats()
+		c.Write(packets.UserEffectLevelUp())
+		if f := c.Field(); f != nil {
+			f.BroadcastExcept(packets.UserEffectRemoteLevelUp(c.ID()), c)
+		}
+	}
+}
+
+// levelUp raises the character one level, granting AP, SP and max HP/MP, and
+// records the changed stats
+func (c *Character) levelUp(stats map[int32]int64) {
+	m := c.model
+	job := game.Job(m.Job)
+
+	m.Level++
+
+	ap := int16(levelUpAP)
+	if job.IsCygnus() && m.Level <= cygnusBonusAPLevel {
+		ap = levelUpAPCygnus
+	}
+	m.AP += ap
+
+	var sp int16
+	switch {
+	case !job.IsBeginner():
+		sp = levelUpSP
+	case m.Level <= beginnerSPLevel:
+		sp = 1
+	}
+	m.SP += sp
+
+	hp, mp := levelUpHPMP(job, int32(m.INT))
+	m.MaxHP = min(m.MaxHP+hp, maxHPMP)
+	m.MaxMP = min(m.MaxMP+mp, maxHPMP)
+	m.HP = m.MaxHP
+	m.MP = m.MaxMP
+
+	stats[packets.StatLevel] = int64(m.Level)
+	stats[packets.StatAP] = int64(m.AP)
+	stats[packets.StatSP] = int64(m.SP)
+	stats[packets.StatMaxHP] = int64(m.MaxHP)
+	stats[packets.StatMaxMP] = int64(m.MaxMP)
+	stats[packets.StatHP] = int64(m.HP)
+	stats[packets.StatMP] = int64(m.MP)
+}
+
+// levelUpHPMP rolls the max HP and MP gained on level up for a job branch.
+// Magicians also gain MP from their INT.
+func levelUpHPMP(job game.Job, intl int32) (hp, mp int32) {
+	roll := func(lo, hi int32) int32 {
+		return lo + rand.Int32N(hi-lo+1)
+	}
+
+	if job.IsBeginner() {
+		return roll(12, 16), roll(10, 12)
+	}
+
+	switch job.Category() {
+	case 1: // Warrior
+		return roll(24, 28), roll(4, 6)
+	case 2: // Magician
+		return roll(10, 14), roll(22, 24) + intl/10
+	case 3, 4: // Bowman, thief
+		return roll(20, 24), roll(14, 16)
+	case 5: // Pirate
+		return roll(22, 28), roll(18, 23)
+	default:
+		return roll(12, 16), roll(10, 12)
+	}
+}
@@ -14,20 +14,27 @@ type MapDataProvider interface {
 	GetMapData(mapID int32) (*providers.MapData, error)
 }
 
+// MobTemplateProvider defines the interface for loading mob templates
+type MobTemplateProvider interface {
+	GetMobTemplate(mobID int32) *providers.MobTemplate
+}
+
 type Manager struct {
 	mu          sync.RWMutex
 	fields      map[int32]*Field
 	mapProvider MapDataProvider
+	mobProvider MobTemplateProvider
 	sf          singleflight.Group
 }
 
-func NewManager(mapProvider MapDataProvider) *Manager {
+func NewManager(mapProvider MapDataProvider, mobProvider MobTemplateProvider) *Manager {
 	if mapProvider == nil {
 		panic("field.Manager: mapProvider is nil")
 	}
 	return &Manager{
 		fields:      make(map[int32]*Field),
 		mapProvider: mapProvider,
+		mobProvider: mobProvider,
 	}
 }
 
@@ -58,7 +65,7 @@ func (m *Manager) GetField(mapID int32) (*Field, error) {
 		}
 
 		// Create field instance with the map data
-		f := NewField(mapData) // NewField starts ticking
+		f := NewField(mapData, m.mobProvider) // NewField starts ticking
 
 		m.mu.Lock()
 		if existing := m.fields[mapID]; existing != nil {
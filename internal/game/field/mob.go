@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/Jinw00Arise/Jinwoo/internal/data/providers"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
 )
 
 // Mob represents a monster entity in the game field.
@@ -24,13 +25,23 @@ type Mob struct {
 
 	// Spawn data for respawning
 	spawnData *providers.LifeSpawn
-	mobTime   int32 // Respawn time in milliseconds (0 = no respawn)
+	mobTime   int32 // Respawn time in seconds (0 = regular regen, <0 = never)
+
+	// Template data from Mob.wz
+	template *providers.MobTemplate
 
 	// Combat state
 	hp    int32
 	maxHP int32
 	mp    int32
 	maxMP int32
+	exp   int32
+
+	// Damage dealt per character, used to split EXP on death
+	attackers map[uint]int64
+
+	// Active buffs and debuffs
+	tempStats packets.MobTemporaryStats
 
 	posMu sync.RWMutex
 }
@@ -50,11 +61,14 @@ func NewMob(objectID int32, spawn *providers.LifeSpawn) *Mob {
 		hide:       spawn.Hide,
 		spawnData:  spawn,
 		mobTime:    spawn.MobTime,
-		// HP/MP will be set from mob data provider later
+		// Placeholder stats until SetStats is called with the mob's template
 		hp:    100,
 		maxHP: 100,
 		mp:    0,
 		maxMP: 0,
+
+		attackers: make(map[uint]int64),
+		tempStats: make(packets.MobTemporaryStats),
 	}
 }
 
@@ -198,7 +212,7 @@ func (m *Mob) IsDead() bool {
 	return m.HP() <= 0
 }
 
-// MobTime returns the respawn time in milliseconds
+// MobTime returns the respawn time in seconds
 func (m *Mob) MobTime() int32 {
 	return m.mobTime
 }
@@ -208,24 +222,75 @@ func (m *Mob) SpawnData() *providers.LifeSpawn {
 	return m.spawnData
 }
 
-// SetStats sets the mob's HP and MP from mob data
-func (m *Mob) SetStats(maxHP, maxMP int32) {
+// SetStats sets the mob's template data and refills its HP and MP
+func (m *Mob) SetStats(template *providers.MobTemplate) {
 	m.posMu.Lock()
-	m.maxHP = maxHP
-	m.hp = maxHP
-	m.maxMP = maxMP
-	m.mp = maxMP
+	m.template = template
+	m.maxHP = max(template.MaxHP, 1)
+	m.hp = m.maxHP
+	m.maxMP = template.MaxMP
+	m.mp = template.MaxMP
+	m.exp = template.EXP
 	m.posMu.Unlock()
 }
 
-// Damage reduces the mob's HP by the given amount and returns the actual damage dealt
-func (m *Mob) Damage(amount int32) int32 {
+// Template returns the mob's template data, or nil if none was loaded
+func (m *Mob) Template() *providers.MobTemplate {
+	m.posMu.RLock()
+	defer m.posMu.RUnlock()
+	return m.template
+}
+
+// Level returns the mob's level
+func (m *Mob) Level() int32 {
+	if t := m.Template(); t != nil {
+		return t.Level
+	}
+	return 1
+}
+
+// IsBoss returns true if the mob is a boss
+func (m *Mob) IsBoss() bool {
+	if t := m.Template(); t != nil {
+		return t.Boss
+	}
+	return false
+}
+
+// EXP returns the base EXP the mob gives when killed
+func (m *Mob) EXP() int32 {
+	m.posMu.RLock()
+	defer m.posMu.RUnlock()
+	return m.exp
+}
+
+// Damage reduces the mob's HP by the given amount on behalf of a character.
+// Returns the damage actually dealt and whether this hit killed the mob;
+// killed is reported exactly once, to the attacker that landed the last hit.
+func (m *Mob) Damage(attackerID uint, amount int32) (dealt int32, killed bool) {
 	m.posMu.Lock()
 	defer m.posMu.Unlock()
 
+	if m.hp <= 0 || amount <= 0 {
+		return 0, false
+	}
+
 	if amount > m.hp {
 		amount = m.hp
 	}
 	m.hp -= amount
-	return amount
+	m.attackers[attackerID] += int64(amount)
+	return amount, m.hp <= 0
+}
+
+// Attackers returns a copy of the damage dealt per character
+func (m *Mob) Attackers() map[uint]int64 {
+	m.posMu.RLock()
+	defer m.posMu.RUnlock()
+
+	result := make(map[uint]int64, len(m.attackers))
+	for id, dmg := range m.attackers {
+		result[id] = dmg
+	}
+	return result
 }
@@ -0,0 +1,56 @@
+package field
+
+import "github.com/Jinw00Arise/Jinwoo/internal/protocol"
+
+// Point is an x/y pair in field coordinates
+type Point struct {
+	X int32
+	Y int32
+}
+
+// MobMoveInfo is a decoded mob movement request from the mob's controller
+type MobMoveInfo struct {
+	ObjectID     int32
+	CtrlSN       uint16
+	ActionMask   byte // bSomeRand | 4 * (bRushMove | 2 * (bRiseByToss | 2 * nMobCtrlState))
+	ActionAndDir byte
+	TargetInfo   int32 // Skill ID/level or attack target for the next action
+	MultiTarget  []Point
+	RandTimes    []int32
+	MovePath     *MovePath
+}
+
+// NextAttackPossible returns the low action flag, which is echoed back to clients as bNextAttackPossible
+func (m *MobMoveInfo) NextAttackPossible() bool {
+	return m.ActionMask&0x01 != 0
+}
+
+// DecodeMobMove reads a v95 mob move request
+func DecodeMobMove(reader *protocol.Reader) *MobMoveInfo {
+	info := &MobMoveInfo{}
+
+	info.ObjectID = reader.ReadInt()
+	info.CtrlSN = reader.ReadShort()
+	info.ActionMask = reader.ReadByte()
+	info.ActionAndDir = reader.ReadByte()
+	info.TargetInfo = reader.ReadInt()
+
+	multiTargetCount := int(reader.ReadInt())
+	for i := 0; i < multiTargetCount && reader.Remaining() >= 8; i++ {
+		info.MultiTarget = append(info.MultiTarget, Point{X: reader.ReadInt(), Y: reader.ReadInt()})
+	}
+
+	randTimeCount := int(reader.ReadInt())
+	for i := 0; i < randTimeCount && reader.Remaining() >= 4; i++ {
+		info.RandTimes = append(info.RandTimes, reader.ReadInt())
+	}
+
+	_ = reader.ReadByte() // bActive == 0 | IsCheatMobMoveRand << 4
+	_ = reader.ReadInt()  // HackedCode
+	_ = reader.ReadInt()  // ptTarget.x
+	_ = reader.ReadInt()  // ptTarget.y
+	_ = reader.ReadInt()  // dwHackedCodeCRC
+
+	info.MovePath = DecodeMovePath(reader)
+	return info
+}
@@ -0,0 +1,157 @@
+package field
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/consts"
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+)
+
+// mobsPerSpawnPoint is how many mobs a regular spawn point holds at most once the
+// field is busy enough to need them; points with their own mobTime hold one
+const mobsPerSpawnPoint = 2
+
+// mobSpawnPoint tracks the mobs spawned from a map life entry
+type mobSpawnPoint struct {
+	spawn     *providers.LifeSpawn
+	objectIDs []int32   // Object IDs of the mobs currently spawned here
+	nextSpawn time.Time // Earliest time the point may spawn again after losing a mob
+}
+
+// regular returns true for spawn points that follow the map's normal regen
+func (sp *mobSpawnPoint) regular() bool {
+	return sp.spawn.MobTime == 0
+}
+
+// full returns true if the point holds as many mobs as it can
+func (sp *mobSpawnPoint) full() bool {
+	if sp.regular() {
+		return len(sp.objectIDs) >= mobsPerSpawnPoint
+	}
+	return len(sp.objectIDs) > 0
+}
+
+// respawnDelay returns how long the point stays empty after its mob is gone
+func (sp *mobSpawnPoint) respawnDelay() time.Duration {
+	if sp.spawn.MobTime > 0 {
+		return time.Duration(sp.spawn.MobTime) * time.Second
+	}
+	return consts.MobRespawnInterval
+}
+
+// newMob creates a mob for a spawn point with its template stats applied
+func (f *Field) newMob(spawn *providers.LifeSpawn) *Mob {
+	mob := NewMob(f.NextObjectID(), spawn)
+	if f.mobProvider != nil {
+		if template := f.mobProvider.GetMobTemplate(spawn.ID); template != nil {
+			mob.SetStats(template)
+		}
+	}
+	return mob
+}
+
+// releaseSpawnPoint frees the spawn point of a removed mob and starts its respawn timer
+func (f *Field) releaseSpawnPoint(objectID int32, now time.Time) {
+	f.spawnMu.Lock()
+	defer f.spawnMu.Unlock()
+
+	for _, sp := range f.mobSpawns {
+		if i := slices.Index(sp.objectIDs, objectID); i >= 0 {
+			sp.objectIDs = slices.Delete(sp.objectIDs, i, i+1)
+			sp.nextSpawn = now.Add(sp.respawnDelay())
+			return
+		}
+	}
+}
+
+// mobCapacityLocked returns how many mobs the regular spawn points may hold at once
+// (spawnMu held). As in the client's life pool, the base is the spawn points scaled
+// by the map's mob rate, growing towards twice that as more characters enter, and
+// capped by what the spawn points can hold.
+func (f *Field) mobCapacityLocked(characters int) int {
+	regular := 0
+	for _, sp := range f.mobSpawns {
+		if sp.regular() {
+			regular++
+		}
+	}
+	if regular == 0 {
+		return 0
+	}
+
+	base := max(int(math.Ceil(float64(regular)*f.mapData.MobRate)), 1)
+	capacity := base
+	if characters > base/2 {
+		capacity += base * (2*characters - base) / (3 * base)
+	}
+	return min(capacity, 2*base, regular*mobsPerSpawnPoint)
+}
+
+// respawnMobs refills spawn points whose timers have run out, emptiest first. Mobs
+// only come back while someone is in the field, and regular points are capped by
+// the field's mob capacity; points with their own mobTime (bosses) are not.
+func (f *Field) respawnMobs(now time.Time) {
+	characters := f.CharacterCount()
+	if characters == 0 {
+		return
+	}
+
+	var spawned []*Mob
+
+	f.spawnMu.Lock()
+	alive := 0
+	for _, sp := range f.mobSpawns {
+		if sp.regular() {
+			alive += len(sp.objectIDs)
+		}
+	}
+	capacity := f.mobCapacityLocked(characters)
+
+	points := make([]*mobSpawnPoint, len(f.mobSpawns))
+	for i, j := range rand.Perm(len(f.mobSpawns)) {
+		points[i] = f.mobSpawns[j]
+	}
+	slices.SortStableFunc(points, func(a, b *mobSpawnPoint) int {
+		return len(a.objectIDs) - len(b.objectIDs)
+	})
+
+	for _, sp := range points {
+		if sp.full() || sp.spawn.MobTime < 0 || now.Before(sp.nextSpawn) {
+			continue
+		}
+		if sp.regular() {
+			if alive >= capacity {
+				continue
+			}
+			alive++
+		}
+
+		mob := f.newMob(sp.spawn)
+		sp.objectIDs = append(sp.objectIDs, mob.ObjectID())
+		spawned = append(spawned, mob)
+	}
+	f.spawnMu.Unlock()
+
+	for _, mob := range spawned {
+		f.mobs.Add(mob)
+		f.Broadcast(packets.MobEnterField(mob))
+		f.assignMobController(mob)
+	}
+}
+
+// assignMobController hands control of a mob to a character in the field
+func (f *Field) assignMobController(mob *Mob) {
+	chars := f.GetAllCharacters()
+	if len(chars) == 0 {
+		mob.AssignController(nil)
+		return
+	}
+
+	controller := chars[0]
+	mob.AssignController(controller)
+	controller.Write(packets.MobChangeController(mob, 1, true))
+}
@@ -1,6 +1,19 @@
 package field
 
-import "github.com/Jinw00Arise/Jinwoo/internal/protocol"
+import (
+	"math"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+const (
+	// speedTolerance allows for lag and slopes when checking walking velocity against speed
+	speedTolerance = 1.5
+
+	// skillMoveTolerance is the extra velocity allowed on walking elements after a jump,
+	// flash jump, teleport or skill movement in the same path, which carry momentum into it
+	skillMoveTolerance = 3.0
+)
 
 // Life is an interface for entities that can move (users, mobs, npcs)
 type Life interface {
@@ -91,6 +104,24 @@ func (mp *MovePath) ApplyTo(life Life) {
 	}
 }
 
+// WithinSpeed checks that no walking element of the path moves faster than a character
+// with the given speed stat can. Jumps, falls and teleports are not checked themselves,
+// but walking after them in the same path is allowed the momentum they carry.
+func (mp *MovePath) WithinSpeed(speed int32) bool {
+	limit := float64(WalkVelocity) * float64(speed) / 100 * speedTolerance
+	for _, elem := range mp.MoveElems {
+		switch MoveTypeFromAttr(elem.Attr) {
+		case MoveTypeNormal:
+			if math.Abs(float64(int16(elem.Vx))) > limit {
+				return false
+			}
+		case MoveTypeJump, MoveTypeTeleport, MoveTypeFlyingBlock, MoveTypeAction:
+			limit = float64(WalkVelocity) * float64(speed) / 100 * speedTolerance * skillMoveTolerance
+		}
+	}
+	return true
+}
+
 func (mp *MovePath) Encode(p *protocol.Packet) {
 	p.WriteShort(uint16(mp.X))
 	p.WriteShort(uint16(mp.Y))
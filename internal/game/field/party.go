@@ -0,0 +1,58 @@
+package field
+
+import "github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+
+// SetPartyID sets the character's party, or clears it when partyID is 0
+func (c *Character) SetPartyID(partyID uint) {
+	if c.model == nil {
+		return
+	}
+	if partyID == 0 {
+		c.model.PartyID = nil
+		return
+	}
+	c.model.PartyID = &partyID
+}
+
+// PartyMembers returns the other characters on the field in the same party as c
+func (f *Field) PartyMembers(c *Character) []*Character {
+	partyID := c.PartyID()
+	if partyID == 0 {
+		return nil
+	}
+
+	var members []*Character
+	for _, other := range f.GetAllCharacters() {
+		if other.ID() != c.ID() && other.PartyID() == partyID {
+			members = append(members, other)
+		}
+	}
+	return members
+}
+
+// BroadcastPartyHP shows the character's HP bar to party members on the same field
+func (c *Character) BroadcastPartyHP() {
+	f := c.Field()
+	if f == nil || c.PartyID() == 0 {
+		return
+	}
+
+	p := packets.UserHP(c.ID(), c.HP(), c.CalcStats().MaxHP)
+	for _, member := range f.PartyMembers(c) {
+		member.Write(p)
+	}
+}
+
+// ShowPartyHP swaps HP bars between the character and its party members on the field,
+// e.g. after entering a field or joining a party
+func (c *Character) ShowPartyHP() {
+	f := c.Field()
+	if f == nil {
+		return
+	}
+
+	for _, member := range f.PartyMembers(c) {
+		c.Write(packets.UserHP(member.ID(), member.HP(), member.CalcStats().MaxHP))
+	}
+	c.BroadcastPartyHP()
+}
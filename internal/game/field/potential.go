@@ -0,0 +1,321 @@
+package field
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers"
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers/item"
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/utils"
+)
+
+const (
+	// MiracleCubeItemID re-rolls the potential of a revealed equip
+	MiracleCubeItemID int32 = 5062000
+
+	// Equip grade byte: hidden potential shows as 1, revealed potential is the tier with the released bit
+	gradeHidden   byte = 1
+	gradeReleased byte = 4
+
+	// primeLineChance is how likely the 2nd and 3rd lines roll at the item's tier instead of one below
+	primeLineChance = 0.1
+	// thirdLineChance is how likely a revealed potential gets a 3rd line
+	thirdLineChance = 0.2
+
+	maxOptionLevel int32 = 20
+)
+
+// Option weights: flat stat lines are the most common, percentage lines rarer and
+// special lines (skill procs, invincibility, ...) the rarest
+const (
+	flatOptionWeight    = 10
+	percentOptionWeight = 4
+	specialOptionWeight = 1
+)
+
+// flatOptionProps are the option props that add a flat amount to a stat
+var flatOptionProps = map[string]bool{
+	"incSTR": true, "incDEX": true, "incINT": true, "incLUK": true,
+	"incMHP": true, "incMMP": true, "incPAD": true, "incMAD": true,
+	"incPDD": true, "incMDD": true, "incACC": true, "incEVA": true,
+	"incSpeed": true, "incJump": true,
+}
+
+// hiddenTierChances are the odds of each tier when an equip rolls hidden potential, best first
+var hiddenTierChances = []struct {
+	tier   item.ItemGrade
+	chance float64
+}{
+	{item.ItemGradeUnique, 0.01},
+	{item.ItemGradeEpic, 0.05},
+	{item.ItemGradeRare, 1},
+}
+
+// cubeTierUpChances are the odds of a miracle cube raising a potential to the next tier
+var cubeTierUpChances = map[item.ItemGrade]float64{
+	item.ItemGradeRare: 0.06,
+	item.ItemGradeEpic: 0.02,
+}
+
+// isMagnifyingGlass checks if an item reveals hidden potential (246xxxx)
+func isMagnifyingGlass(itemID int32) bool {
+	return itemID/10_000 == 246
+}
+
+// HasHiddenPotential checks if an equip has potential waiting to be revealed
+func HasHiddenPotential(it *models.CharacterItem) bool {
+	return it.Grade == gradeHidden && it.Option1 < 0
+}
+
+// PotentialTier returns the tier of an equip's revealed potential, or ItemGradeNormal if none
+func PotentialTier(it *models.CharacterItem) item.ItemGrade {
+	if it.Grade&gradeReleased == 0 {
+		return item.ItemGradeNormal
+	}
+	return item.ItemGrade(it.Grade &^ gradeReleased)
+}
+
+// AddHiddenPotential gives an equip hidden potential of a random tier.
+// The tier is kept as a negative first option until the potential is revealed.
+func AddHiddenPotential(it *models.CharacterItem) {
+	if utils.GetItemTypeByItemID(it.ItemID) != utils.ItemTypeEquip || it.Cash {
+		return
+	}
+	tier := item.ItemGradeRare
+	for _, c := range hiddenTierChances {
+		if rand.Float64() < c.chance {
+			tier = c.tier
+			break
+		}
+	}
+	it.Grade = gradeHidden
+	it.Option1 = -int16(tier)
+	it.Option2 = 0
+	it.Option3 = 0
+}
+
+// RevealPotential uses a magnifying glass from a use slot on an equip with hidden potential
+// and sends the inventory changes to the client
+func (c *Character) RevealPotential(glassSlot, equipSlot int16) error {
+	if c.itemProvider == nil {
+		return fmt.Errorf("no item provider")
+	}
+
+	c.posMu.Lock()
+	ops, err := c.revealPotentialLocked(glassSlot, equipSlot)
+	c.posMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	c.Write(packets.InventoryOperationPacket(true, ops))
+	if equipSlot < 0 {
+		c.RecalcStats()
+	}
+	return nil
+}
+
+func (c *Character) revealPotentialLocked(glassSlot, equipSlot int16) ([]packets.InventoryOperation, error) {
+	glass := c.itemAtLocked(models.InvConsume, glassSlot)
+	if glass == nil || !isMagnifyingGlass(glass.ItemID) {
+		return nil, fmt.Errorf("no magnifying glass in slot %d", glassSlot)
+	}
+	equipInv := slotInventoryType(models.InvEquip, equipSlot)
+	equip := c.itemAtLocked(equipInv, equipSlot)
+	if equip == nil || !HasHiddenPotential(equip) {
+		return nil, fmt.Errorf("no hidden potential in slot %d", equipSlot)
+	}
+
+	reqLevel := equipReqLevel(c.itemProvider, equip.ItemID)
+	if info := c.itemProvider.GetItemInfo(glass.ItemID); info != nil {
+		if maxLevel := info.GetInfoOr(item.KeyReqEquipLevelMax, 0); maxLevel > 0 && reqLevel > maxLevel {
+			return nil, fmt.Errorf("magnifying glass %d cannot reveal level %d item", glass.ItemID, reqLevel)
+		}
+	}
+
+	tier := item.ItemGrade(-equip.Option1)
+	lines := 2
+	if rand.Float64() < thirdLineChance {
+		lines = 3
+	}
+	if err := rollPotential(c.itemProvider, equip, tier, lines); err != nil {
+		return nil, err
+	}
+
+	return []packets.InventoryOperation{
+		c.consumeOneLocked(glass),
+		{Type: packets.InventoryOpAdd, InvType: equipInv, Slot: equipSlot, Item: equip},
+	}, nil
+}
+
+// CubePotential uses a miracle cube from a cash slot to re-roll a revealed potential, with a
+// chance of moving up a tier. The inventory changes are sent to the client.
+// Returns whether the tier went up.
+func (c *Character) CubePotential(cubeSlot, equipSlot int16) (bool, error) {
+	if c.itemProvider == nil {
+		return false, fmt.Errorf("no item provider")
+	}
+
+	c.posMu.Lock()
+	tierUp, ops, err := c.cubePotentialLocked(cubeSlot, equipSlot)
+	c.posMu.Unlock()
+	if err != nil {
+		return false, err
+	}
+
+	c.Write(packets.InventoryOperationPacket(true, ops))
+	if equipSlot < 0 {
+		c.RecalcStats()
+	}
+	return tierUp, nil
+}
+
+func (c *Character) cubePotentialLocked(cubeSlot, equipSlot int16) (bool, []packets.InventoryOperation, error) {
+	cube := c.itemAtLocked(models.InvCash, cubeSlot)
+	if cube == nil || cube.ItemID != MiracleCubeItemID {
+		return false, nil, fmt.Errorf("no miracle cube in slot %d", cubeSlot)
+	}
+	equipInv := slotInventoryType(models.InvEquip, equipSlot)
+	equip := c.itemAtLocked(equipInv, equipSlot)
+	if equip == nil {
+		return false, nil, fmt.Errorf("no equip in slot %d", equipSlot)
+	}
+	tier := PotentialTier(equip)
+	if tier == item.ItemGradeNormal {
+		return false, nil, fmt.Errorf("item %d has no revealed potential", equip.ItemID)
+	}
+
+	tierUp := rand.Float64() < cubeTierUpChances[tier]
+	if tierUp {
+		tier++
+	}
+	lines := 2
+	if equip.Option3 != 0 {
+		lines = 3
+	}
+	if err := rollPotential(c.itemProvider, equip, tier, lines); err != nil {
+		return false, nil, err
+	}
+
+	return tierUp, []packets.InventoryOperation{
+		c.consumeOneLocked(cube),
+		{Type: packets.InventoryOpAdd, InvType: equipInv, Slot: equipSlot, Item: equip},
+	}, nil
+}
+
+// rollPotential picks new option lines for an equip and marks its potential as revealed.
+// The first line is always of the given tier, the others usually one tier below. Lines
+// are drawn by weight from the options for the equip's type and level.
+func rollPotential(provider *providers.ItemProvider, equip *models.CharacterItem, tier item.ItemGrade, lines int) error {
+	bodyParts := utils.GetBodyPartsByItemID(equip.ItemID)
+	if len(bodyParts) == 0 {
+		return fmt.Errorf("item %d has no body part", equip.ItemID)
+	}
+	reqLevel := equipReqLevel(provider, equip.ItemID)
+	level := optionLevel(reqLevel)
+
+	options := make([]int16, 3)
+	for i := range lines {
+		lineTier := tier
+		if i > 0 && rand.Float64() >= primeLineChance {
+			lineTier--
+		}
+		option := pickOption(provider.GetMatchingItemOptions(lineTier, reqLevel, bodyParts[0]), level)
+		if option == nil {
+			return fmt.Errorf("no tier %d options for item %d", lineTier, equip.ItemID)
+		}
+		options[i] = int16(option.GetItemOptionID())
+	}
+
+	equip.Grade = gradeReleased | byte(tier)
+	equip.Option1, equip.Option2, equip.Option3 = options[0], options[1], options[2]
+	return nil
+}
+
+// pickOption draws one of the candidate options by weight, skipping options with no
+// stats at the equip's option level. Returns nil if none qualify.
+func pickOption(candidates []*item.ItemOptionInfo, level int32) *item.ItemOptionInfo {
+	weights := make([]int, len(candidates))
+	total := 0
+	for i, option := range candidates {
+		data, ok := option.GetLevelData(level)
+		if !ok || len(data.Props) == 0 {
+			continue
+		}
+		weights[i] = optionWeight(data)
+		total += weights[i]
+	}
+	if total == 0 {
+		return nil
+	}
+
+	n := rand.IntN(total)
+	for i, w := range weights {
+		if n < w {
+			return candidates[i]
+		}
+		n -= w
+	}
+	return nil
+}
+
+// optionWeight returns how likely an option is to be rolled, from the stats it gives
+func optionWeight(data *item.ItemOptionLevelData) int {
+	weight := flatOptionWeight
+	for prop := range data.Props {
+		switch {
+		case flatOptionProps[prop]:
+		case flatOptionProps[strings.TrimSuffix(prop, "r")]:
+			weight = min(weight, percentOptionWeight)
+		default:
+			weight = specialOptionWeight
+		}
+	}
+	return weight
+}
+
+// equipReqLevel returns an equip's required level from its item info
+func equipReqLevel(provider *providers.ItemProvider, itemID int32) int32 {
+	if info := provider.GetItemInfo(itemID); info != nil {
+		return info.GetInfoOr(item.KeyReqLevel, 0)
+	}
+	return 0
+}
+
+// optionLevel returns the ItemOption.img level used for an equip's required level
+func optionLevel(reqLevel int32) int32 {
+	return max(1, min((reqLevel+9)/10, maxOptionLevel))
+}
+
+// PotentialStats sums the potential options of all worn equips
+func (c *Character) PotentialStats() StatBonus {
+	stats := make(StatBonus)
+	if c.itemProvider == nil {
+		return stats
+	}
+
+	for _, equip := range c.Equipped() {
+		if PotentialTier(equip) == item.ItemGradeNormal {
+			continue
+		}
+		level := optionLevel(equipReqLevel(c.itemProvider, equip.ItemID))
+		for _, optionID := range []int16{equip.Option1, equip.Option2, equip.Option3} {
+			if optionID <= 0 {
+				continue
+			}
+			info := c.itemProvider.GetItemOptionInfo(int32(optionID))
+			if info == nil {
+				continue
+			}
+			data, ok := info.GetLevelData(level)
+			if !ok {
+				continue
+			}
+			stats.add(data.Props)
+		}
+	}
+	return stats
+}
@@ -0,0 +1,192 @@
+package field
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers/item"
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+)
+
+const (
+	// WhiteScrollItemID protects an equip's upgrade slot when a scroll fails
+	WhiteScrollItemID int32 = 2340000
+
+	// defaultChaosRange is how far a chaos scroll moves each stat without incRandVol
+	defaultChaosRange int32 = 5
+)
+
+// ScrollResult is the outcome of applying an upgrade scroll
+type ScrollResult struct {
+	Success     bool
+	Destroyed   bool
+	WhiteScroll bool // A white scroll was consumed
+	Equipped    bool // The scrolled item is worn, so the avatar needs a refresh
+}
+
+// UpgradeEquip applies the scroll in a use slot to the equip in an equip slot. Normal scrolls
+// add their stats and use up a slot, clean slates recover failed slots and chaos scrolls
+// randomise every existing stat. The inventory changes are sent to the client.
+func (c *Character) UpgradeEquip(scrollSlot, equipSlot int16, whiteScroll bool) (ScrollResult, error) {
+	if c.itemProvider == nil {
+		return ScrollResult{}, fmt.Errorf("no item provider")
+	}
+
+	c.posMu.Lock()
+	result, ops, err := c.upgradeEquipLocked(scrollSlot, equipSlot, whiteScroll)
+	c.posMu.Unlock()
+	if err != nil {
+		return ScrollResult{}, err
+	}
+
+	c.Write(packets.InventoryOperationPacket(true, ops))
+	if result.Equipped {
+		c.RecalcStats()
+	}
+	return result, nil
+}
+
+func (c *Character) upgradeEquipLocked(scrollSlot, equipSlot int16, whiteScroll bool) (ScrollResult, []packets.InventoryOperation, error) {
+	scroll := c.itemAtLocked(models.InvConsume, scrollSlot)
+	if scroll == nil || !isUpgradeScroll(scroll.ItemID) {
+		return ScrollResult{}, nil, fmt.Errorf("no scroll in slot %d", scrollSlot)
+	}
+	equipInv := slotInventoryType(models.InvEquip, equipSlot)
+	equip := c.itemAtLocked(equipInv, equipSlot)
+	if equip == nil || equip.Cash {
+		return ScrollResult{}, nil, fmt.Errorf("no scrollable equip in slot %d", equipSlot)
+	}
+	if !canScroll(scroll.ItemID, equip.ItemID) {
+		return ScrollResult{}, nil, fmt.Errorf("scroll %d cannot be used on item %d", scroll.ItemID, equip.ItemID)
+	}
+
+	info := c.itemProvider.GetItemInfo(scroll.ItemID)
+	equipInfo := c.itemProvider.GetItemInfo(equip.ItemID)
+	if info == nil || equipInfo == nil {
+		return ScrollResult{}, nil, fmt.Errorf("missing item info for scroll %d on item %d", scroll.ItemID, equip.ItemID)
+	}
+
+	recoverSlots := info.GetInfoOr(item.KeyRecover, 0)
+	failedSlots := equipInfo.GetInfoOr(item.KeyTUC, 0) - int32(equip.RUC) - int32(equip.CUC)
+	if recoverSlots > 0 {
+		if failedSlots <= 0 {
+			return ScrollResult{}, nil, fmt.Errorf("item %d has no failed slots to recover", equip.ItemID)
+		}
+		// Clean slates don't burn a slot, so a white scroll would be wasted
+		whiteScroll = false
+	} else if equip.RUC == 0 {
+		return ScrollResult{}, nil, fmt.Errorf("item %d has no upgrade slots left", equip.ItemID)
+	}
+
+	var white *models.CharacterItem
+	if whiteScroll {
+		for _, it := range c.sortedItemsLocked(models.InvConsume) {
+			if it.ItemID == WhiteScrollItemID {
+				white = it
+				break
+			}
+		}
+		if white == nil {
+			return ScrollResult{}, nil, fmt.Errorf("no white scroll to protect item %d", equip.ItemID)
+		}
+	}
+
+	result := ScrollResult{WhiteScroll: white != nil, Equipped: equipInv == models.InvEquipped}
+	result.Success = rand.Int32N(100) < info.GetInfoOr(item.KeySuccess, 100)
+
+	switch {
+	case result.Success && recoverSlots > 0:
+		equip.RUC += byte(min(recoverSlots, failedSlots))
+	case result.Success:
+		if info.GetInfoFlag(item.KeyRandStat) {
+			applyChaosScroll(equip, info.GetInfoOr(item.KeyIncRandVol, defaultChaosRange))
+		} else {
+			applyScrollStats(equip, info)
+		}
+		equip.RUC--
+		equip.CUC++
+	default:
+		if recoverSlots == 0 && white == nil {
+			equip.RUC--
+		}
+		result.Destroyed = rand.Int32N(100) < info.GetInfoOr(item.KeyCursed, 0)
+	}
+
+	ops := []packets.InventoryOperation{c.consumeOneLocked(scroll)}
+	if white != nil {
+		ops = append(ops, c.consumeOneLocked(white))
+	}
+
+	if result.Destroyed {
+		c.deleteItemLocked(equip)
+		ops = append(ops, packets.InventoryOperation{Type: packets.InventoryOpRemove, InvType: equipInv, Slot: equipSlot})
+	} else {
+		// Re-adding the equip in place refreshes its stats on the client
+		ops = append(ops, packets.InventoryOperation{Type: packets.InventoryOpAdd, InvType: equipInv, Slot: equipSlot, Item: equip})
+	}
+	return result, ops, nil
+}
+
+// consumeOneLocked uses up one item of a stack and returns the matching operation
+func (c *Character) consumeOneLocked(it *models.CharacterItem) packets.InventoryOperation {
+	it.Quantity--
+	if it.Quantity <= 0 {
+		c.deleteItemLocked(it)
+		return packets.InventoryOperation{Type: packets.InventoryOpRemove, InvType: it.InvType, Slot: it.Slot}
+	}
+	return packets.InventoryOperation{Type: packets.InventoryOpQuantity, InvType: it.InvType, Slot: it.Slot, Quantity: it.Quantity}
+}
+
+// applyScrollStats adds a scroll's stat increments to an equip
+func applyScrollStats(equip *models.CharacterItem, info *item.ItemInfo) {
+	inc := func(key item.ItemInfosKey) int16 {
+		return int16(info.GetInfoOr(key, 0))
+	}
+	equip.IncStr += inc(item.KeyIncSTR)
+	equip.IncDex += inc(item.KeyIncDEX)
+	equip.IncInt += inc(item.KeyIncINT)
+	equip.IncLuk += inc(item.KeyIncLUK)
+	equip.IncMaxHP += inc(item.KeyIncMHP)
+	equip.IncMaxMP += inc(item.KeyIncMMP)
+	equip.IncPAD += inc(item.KeyIncPAD)
+	equip.IncMAD += inc(item.KeyIncMAD)
+	equip.IncPDD += inc(item.KeyIncPDD)
+	equip.IncMDD += inc(item.KeyIncMDD)
+	equip.IncACC += inc(item.KeyIncACC)
+	equip.IncEVA += inc(item.KeyIncEVA)
+	equip.IncSpeed += inc(item.KeyIncSpeed)
+	equip.IncJump += inc(item.KeyIncJump)
+}
+
+// applyChaosScroll moves every non-zero stat of an equip by up to vol in either direction
+func applyChaosScroll(equip *models.CharacterItem, vol int32) {
+	if vol <= 0 {
+		vol = defaultChaosRange
+	}
+	for _, stat := range []*int16{
+		&equip.IncStr, &equip.IncDex, &equip.IncInt, &equip.IncLuk,
+		&equip.IncMaxHP, &equip.IncMaxMP, &equip.IncPAD, &equip.IncMAD,
+		&equip.IncPDD, &equip.IncMDD, &equip.IncACC, &equip.IncEVA,
+		&equip.IncSpeed, &equip.IncJump,
+	} {
+		if *stat == 0 {
+			continue
+		}
+		*stat = max(0, *stat+int16(rand.Int32N(vol*2+1)-vol))
+	}
+}
+
+// isUpgradeScroll checks if an item is an equipment upgrade scroll (204xxxx)
+func isUpgradeScroll(itemID int32) bool {
+	return itemID/10_000 == 204
+}
+
+// canScroll checks whether a scroll fits an equip. General scrolls (2049xxx) work on anything,
+// otherwise the scroll's 3rd and 4th digits must match the equip's category.
+func canScroll(scrollID, equipID int32) bool {
+	if scrollID/1_000 == 2049 {
+		return true
+	}
+	return (scrollID/100)%100 == (equipID/10_000)%100
+}
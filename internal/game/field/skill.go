@@ -0,0 +1,214 @@
+package field
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers"
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+)
+
+// maxMacros is the number of skill macro slots
+const maxMacros = 5
+
+// Skills returns the character's skill records sorted by skill ID
+func (c *Character) Skills() []*models.Skill {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+
+	skills := make([]*models.Skill, 0, len(c.skills))
+	for _, s := range c.skills {
+		skills = append(skills, s)
+	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].SkillID < skills[j].SkillID })
+	return skills
+}
+
+// SetSkills replaces the character's skill records
+func (c *Character) SetSkills(skills []*models.Skill) {
+	c.posMu.Lock()
+	defer c.posMu.Unlock()
+
+	c.skills = make(map[int32]*models.Skill, len(skills))
+	for _, s := range skills {
+		c.skills[s.SkillID] = s
+	}
+}
+
+// SkillLevel returns the character's level in a skill, or 0 if not learned
+func (c *Character) SkillLevel(skillID int32) int32 {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+
+	if s, ok := c.skills[skillID]; ok {
+		return int32(s.Level)
+	}
+	return 0
+}
+
+// MasterLevel returns the character's master level for a skill
+func (c *Character) MasterLevel(skillID int32) int32 {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+
+	if s, ok := c.skills[skillID]; ok {
+		return int32(s.MasterLevel)
+	}
+	return 0
+}
+
+// SetSkill sets a skill's level and master level, creating the record if needed,
+// and notifies the client
+func (c *Character) SetSkill(skillID, level, masterLevel int32) *models.Skill {
+	c.posMu.Lock()
+	s, ok := c.skills[skillID]
+	if !ok {
+		s = &models.Skill{CharacterID: c.ID(), SkillID: skillID}
+		c.skills[skillID] = s
+	}
+	s.Level = byte(level)
+	s.MasterLevel = byte(masterLevel)
+	c.posMu.Unlock()
+
+	c.Write(packets.ChangeSkillRecordResult(true, []*models.Skill{s}))
+	return s
+}
+
+// SkillUp spends one SP on a skill after checking the character's job,
+// the skill's requirements and its level cap
+func (c *Character) SkillUp(skill *providers.SkillTemplate) error {
+	if c.model.SP <= 0 {
+		return errors.New("no SP left")
+	}
+	if skill.Invisible && c.SkillLevel(skill.ID) == 0 && c.MasterLevel(skill.ID) == 0 {
+		return fmt.Errorf("skill %d is hidden", skill.ID)
+	}
+
+	skillJob := game.SkillJob(skill.ID)
+	if !game.Job(c.Job()).CanLearnSkillsOf(skillJob) {
+		return fmt.Errorf("job %d can't learn skills of job %d", c.Job(), skillJob)
+	}
+
+	for reqID, reqLevel := range skill.Reqs {
+		if c.SkillLevel(reqID) < reqLevel {
+			return fmt.Errorf("requires skill %d at level %d", reqID, reqLevel)
+		}
+	}
+
+	level := c.SkillLevel(skill.ID) + 1
+	maxLevel := skill.MaxLevel()
+	if game.IsSkillNeedMasterLevel(skill.ID) {
+		maxLevel = min(maxLevel, c.MasterLevel(skill.ID))
+	}
+	if level > maxLevel {
+		return fmt.Errorf("skill %d is already at its max level %d", skill.ID, maxLevel)
+	}
+
+	c.model.SP--
+	c.Write(packets.StatChanged(false, map[int32]int64{packets.StatSP: int64(c.model.SP)}))
+	c.SetSkill(skill.ID, level, c.MasterLevel(skill.ID))
+	return nil
+}
+
+// Macros returns the character's skill macros
+func (c *Character) Macros() []*models.SkillMacro {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+
+	cpy := make([]*models.SkillMacro, len(c.macros))
+	copy(cpy, c.macros)
+	return cpy
+}
+
+// SetMacros replaces the character's skill macros, dropping any past the last slot
+func (c *Character) SetMacros(macros []*models.SkillMacro) {
+	if len(macros) > maxMacros {
+		macros = macros[:maxMacros]
+	}
+	for i, m := range macros {
+		m.CharacterID = c.ID()
+		m.Position = byte(i)
+	}
+
+	c.posMu.Lock()
+	defer c.posMu.Unlock()
+	c.macros = macros
+}
+
+// Cooldowns returns the character's skill cooldowns that haven't expired yet
+func (c *Character) Cooldowns() []*models.SkillCooldown {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+
+	now := time.Now()
+	cooldowns := make([]*models.SkillCooldown, 0, len(c.cooldowns))
+	for skillID, expiresAt := range c.cooldowns {
+		if expiresAt.After(now) {
+			cooldowns = append(cooldowns, &models.SkillCooldown{
+				CharacterID: c.ID(),
+				SkillID:     skillID,
+				ExpiresAt:   expiresAt,
+			})
+		}
+	}
+	return cooldowns
+}
+
+// SetCooldowns replaces the character's skill cooldowns
+func (c *Character) SetCooldowns(cooldowns []*models.SkillCooldown) {
+	c.posMu.Lock()
+	defer c.posMu.Unlock()
+
+	c.cooldowns = make(map[int32]time.Time, len(cooldowns))
+	for _, cd := range cooldowns {
+		c.cooldowns[cd.SkillID] = cd.ExpiresAt
+	}
+}
+
+// IsOnCooldown returns true if the skill can't be used yet
+func (c *Character) IsOnCooldown(skillID int32) bool {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+	return c.cooldowns[skillID].After(time.Now())
+}
+
+// SetCooldown puts a skill on cooldown for the given duration
+func (c *Character) SetCooldown(skillID int32, d time.Duration) {
+	c.posMu.Lock()
+	defer c.posMu.Unlock()
+	c.cooldowns[skillID] = time.Now().Add(d)
+}
+
+// UseSkill pays a skill's HP/MP cost and starts its cooldown, returning the changed stats
+func (c *Character) UseSkill(skillID int32, lv *providers.SkillLevel) (map[int32]int64, error) {
+	if c.IsOnCooldown(skillID) {
+		return nil, fmt.Errorf("skill %d is on cooldown", skillID)
+	}
+
+	m := c.model
+	if m.MP < lv.MPCon {
+		return nil, fmt.Errorf("skill %d needs %d MP, has %d", skillID, lv.MPCon, m.MP)
+	}
+	if m.HP <= lv.HPCon {
+		return nil, fmt.Errorf("skill %d needs more than %d HP, has %d", skillID, lv.HPCon, m.HP)
+	}
+
+	stats := make(map[int32]int64)
+	if lv.MPCon > 0 {
+		m.MP -= lv.MPCon
+		stats[packets.StatMP] = int64(m.MP)
+	}
+	if lv.HPCon > 0 {
+		m.HP -= lv.HPCon
+		stats[packets.StatHP] = int64(m.HP)
+		c.BroadcastPartyHP()
+	}
+	if lv.Cooltime > 0 {
+		c.SetCooldown(skillID, time.Duration(lv.Cooltime)*time.Second)
+	}
+	return stats, nil
+}
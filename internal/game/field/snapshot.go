@@ -0,0 +1,74 @@
+package field
+
+import (
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+)
+
+// Snapshot is a copy of everything saved for a character. It can be persisted
+// from any goroutine while the character keeps playing.
+type Snapshot struct {
+	Model        models.Character
+	Items        []*models.CharacterItem
+	QuestRecords []*models.QuestRecord
+	Skills       []*models.Skill
+	Macros       []*models.SkillMacro
+	Cooldowns    []*models.SkillCooldown
+
+	live []*models.CharacterItem // Items the copies were taken from, by index
+}
+
+// Snapshot copies the character's saved state. New items get their serial
+// numbers here so saves of overlapping snapshots agree on them. Call it from the
+// character's own client goroutine.
+func (c *Character) Snapshot() *Snapshot {
+	m := c.model
+	if m == nil {
+		return nil
+	}
+
+	c.posMu.RLock()
+	live := make([]*models.CharacterItem, len(c.items))
+	copy(live, c.items)
+	c.posMu.RUnlock()
+
+	s := &Snapshot{live: live}
+	s.Items = make([]*models.CharacterItem, len(live))
+	for i, it := range live {
+		if it.ItemSN == 0 {
+			m.ItemSNCounter++
+			it.ItemSN = int64(m.ItemSNCounter)
+		}
+		cpy := *it
+		s.Items[i] = &cpy
+	}
+	s.Model = *m
+
+	for _, qr := range c.QuestRecords() {
+		cpy := *qr
+		s.QuestRecords = append(s.QuestRecords, &cpy)
+	}
+	for _, sk := range c.Skills() {
+		cpy := *sk
+		s.Skills = append(s.Skills, &cpy)
+	}
+	for _, mc := range c.Macros() {
+		cpy := *mc
+		s.Macros = append(s.Macros, &cpy)
+	}
+	s.Cooldowns = c.Cooldowns()
+	return s
+}
+
+// ApplySnapshot copies the database IDs given to new items by a save back onto
+// the character's items. Call it from the character's own client goroutine.
+func (c *Character) ApplySnapshot(s *Snapshot) {
+	if s == nil {
+		return
+	}
+	for i, it := range s.live {
+		if it.ID == 0 && it.ItemSN == s.Items[i].ItemSN {
+			it.ID = s.Items[i].ID
+			it.CharacterID = s.Items[i].CharacterID
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package field
+
+import (
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+)
+
+// TemporaryStats returns a copy of the character's active buffs and debuffs
+func (c *Character) TemporaryStats() packets.TemporaryStats {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+
+	stats := make(packets.TemporaryStats, len(c.tempStats))
+	for s, v := range c.tempStats {
+		stats[s] = v
+	}
+	return stats
+}
+
+// TemporaryStat returns the value of an active temporary stat
+func (c *Character) TemporaryStat(stat packets.CharacterTemporaryStat) (packets.TemporaryStatValue, bool) {
+	c.posMu.RLock()
+	defer c.posMu.RUnlock()
+	v, ok := c.tempStats[stat]
+	return v, ok
+}
+
+// SetTemporaryStats applies timed stats from one source (a skill ID, or a negated
+// item ID), replacing whatever that source had applied before. The owner gets
+// the full stats and the rest of the field sees the visible ones.
+func (c *Character) SetTemporaryStats(reason int32, duration time.Duration, options map[packets.CharacterTemporaryStat]int32) {
+	if len(options) == 0 || duration <= 0 {
+		return
+	}
+
+	// Recasting replaces the previous cast, so drop anything it set that this one doesn't
+	var stale []packets.CharacterTemporaryStat
+	c.posMu.RLock()
+	for s, v := range c.tempStats {
+		if _, ok := options[s]; !ok && v.Reason == reason {
+			stale = append(stale, s)
+		}
+	}
+	c.posMu.RUnlock()
+	c.resetTemporaryStats(stale)
+
+	expireAt := time.Now().Add(duration)
+	set := make(packets.TemporaryStats, len(options))
+	remote := false
+	for s, option := range options {
+		set[s] = packets.TemporaryStatValue{Option: option, Reason: reason, ExpireAt: expireAt}
+		remote = remote || s.IsRemote()
+	}
+
+	c.posMu.Lock()
+	for s, v := range set {
+		c.tempStats[s] = v
+	}
+	f := c.field
+	c.posMu.Unlock()
+
+	c.Write(packets.TemporaryStatSet(set))
+	if remote && f != nil {
+		f.BroadcastExcept(packets.UserTemporaryStatSet(c.ID(), set), c)
+	}
+	c.RecalcStats()
+}
+
+// CancelTemporaryStats ends every stat applied by the given source
+func (c *Character) CancelTemporaryStats(reason int32) {
+	var stats []packets.CharacterTemporaryStat
+	c.posMu.RLock()
+	for s, v := range c.tempStats {
+		if v.Reason == reason {
+			stats = append(stats, s)
+		}
+	}
+	c.posMu.RUnlock()
+
+	c.resetTemporaryStats(stats)
+}
+
+// expireTemporaryStats ends the stats whose duration has run out
+func (c *Character) expireTemporaryStats(now time.Time) {
+	var stats []packets.CharacterTemporaryStat
+	c.posMu.RLock()
+	for s, v := range c.tempStats {
+		if !v.ExpireAt.After(now) {
+			stats = append(stats, s)
+		}
+	}
+	c.posMu.RUnlock()
+
+	c.resetTemporaryStats(stats)
+}
+
+// resetTemporaryStats removes stats and tells the owner and the field
+func (c *Character) resetTemporaryStats(stats []packets.CharacterTemporaryStat) {
+	if len(stats) == 0 {
+		return
+	}
+
+	var removed []packets.CharacterTemporaryStat
+	remote := false
+	c.posMu.Lock()
+	for _, s := range stats {
+		if _, ok := c.tempStats[s]; ok {
+			delete(c.tempStats, s)
+			removed = append(removed, s)
+			remote = remote || s.IsRemote()
+		}
+	}
+	f := c.field
+	c.posMu.Unlock()
+
+	if len(removed) == 0 {
+		return
+	}
+	c.Write(packets.TemporaryStatReset(removed))
+	if remote && f != nil {
+		f.BroadcastExcept(packets.UserTemporaryStatReset(c.ID(), removed), c)
+	}
+	c.RecalcStats()
+}
+
+// TemporaryStats returns a copy of the mob's active buffs and debuffs
+func (m *Mob) TemporaryStats() packets.MobTemporaryStats {
+	m.posMu.RLock()
+	defer m.posMu.RUnlock()
+
+	stats := make(packets.MobTemporaryStats, len(m.tempStats))
+	for s, v := range m.tempStats {
+		stats[s] = v
+	}
+	return stats
+}
+
+// SetTemporaryStats applies timed stats to the mob and returns the applied set
+// for broadcasting with MobStatSet
+func (m *Mob) SetTemporaryStats(reason int32, duration time.Duration, options map[packets.MobTemporaryStat]int32) packets.MobTemporaryStats {
+	expireAt := time.Now().Add(duration)
+	set := make(packets.MobTemporaryStats, len(options))
+	for s, option := range options {
+		set[s] = packets.TemporaryStatValue{Option: option, Reason: reason, ExpireAt: expireAt}
+	}
+
+	m.posMu.Lock()
+	defer m.posMu.Unlock()
+	for s, v := range set {
+		m.tempStats[s] = v
+	}
+	return set
+}
+
+// removeExpiredTemporaryStats removes and returns the mob's stats that have run out
+func (m *Mob) removeExpiredTemporaryStats(now time.Time) []packets.MobTemporaryStat {
+	m.posMu.Lock()
+	defer m.posMu.Unlock()
+
+	var expired []packets.MobTemporaryStat
+	for s, v := range m.tempStats {
+		if !v.ExpireAt.After(now) {
+			expired = append(expired, s)
+			delete(m.tempStats, s)
+		}
+	}
+	return expired
+}
+
+// SetMobTemporaryStats applies timed stats to a mob and shows them to the field
+func (f *Field) SetMobTemporaryStats(mob *Mob, reason int32, duration time.Duration, options map[packets.MobTemporaryStat]int32) {
+	if len(options) == 0 || duration <= 0 || mob.IsDead() {
+		return
+	}
+	f.Broadcast(packets.MobStatSet(mob.ObjectID(), mob.SetTemporaryStats(reason, duration, options)))
+}
+
+// expireTemporaryStats ends character and mob stats whose duration has run out
+func (f *Field) expireTemporaryStats(now time.Time) {
+	for _, char := range f.characters.GetAll() {
+		char.expireTemporaryStats(now)
+	}
+	for _, mob := range f.mobs.GetAll() {
+		if expired := mob.removeExpiredTemporaryStats(now); len(expired) > 0 {
+			f.Broadcast(packets.MobStatReset(mob.ObjectID(), expired))
+		}
+	}
+}
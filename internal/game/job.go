@@ -128,3 +128,63 @@ func (j Job) IsBeginner() bool {
 	return j == JobBeginner || j == JobNoblesse || j == JobAranBeginner ||
 		j == JobEvanBeginner || j == JobCitizen
 }
+
+// IsCygnus returns true for Noblesse and the Cygnus Knight branches
+func (j Job) IsCygnus() bool {
+	return j/1000 == 1
+}
+
+// MaxLevel returns the highest level characters of the job can reach
+func (j Job) MaxLevel() int {
+	if j.IsCygnus() {
+		return MaxCygnusLevel
+	}
+	return MaxLevel
+}
+
+// IsEvan returns true for Evan and the Evan beginner
+func (j Job) IsEvan() bool {
+	return j == JobEvanBeginner || j/100 == 22
+}
+
+// Category returns the job's class branch (0 beginner, 1 warrior, 2 magician, 3 bowman, 4 thief, 5 pirate)
+func (j Job) Category() int {
+	return int(j%1000) / 100
+}
+
+// IsDualBlade returns true for the Blade Recruit to Blade Master jobs
+func (j Job) IsDualBlade() bool {
+	return j/10 == 43
+}
+
+// BeginnerJob returns the beginner job the character's race starts as
+func (j Job) BeginnerJob() Job {
+	switch {
+	case j.IsEvan():
+		return JobEvanBeginner
+	case j == JobAranBeginner || j/100 == 21:
+		return JobAranBeginner
+	default:
+		return j / 1000 * 1000
+	}
+}
+
+// CanLearnSkillsOf returns true if a character of this job may learn skills
+// belonging to skillJob: their race's beginner skills, or an earlier (or the
+// same) advancement on their own branch
+func (j Job) CanLearnSkillsOf(skillJob Job) bool {
+	if skillJob.IsBeginner() {
+		return skillJob == j.BeginnerJob()
+	}
+	if j.IsBeginner() || skillJob > j {
+		return false
+	}
+	if j.IsEvan() {
+		return skillJob.IsEvan()
+	}
+	if skillJob/100 != j/100 {
+		return false
+	}
+	// First job skills are shared by the whole branch
+	return skillJob%100 == 0 || skillJob/10 == j/10
+}
@@ -0,0 +1,94 @@
+package packets
+
+import (
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+// Drop enter types
+const (
+	DropEnterJustShowing byte = 0 // Appears without animation
+	DropEnterCreate      byte = 1 // Falls out of its source
+	DropEnterOnFoothold  byte = 2 // Already lying on the ground (field entry)
+	DropEnterFadingOut   byte = 3
+)
+
+// Drop leave types
+const (
+	DropLeaveTimeout        byte = 0
+	DropLeaveScreenScroll   byte = 1
+	DropLeavePickedUpByUser byte = 2
+	DropLeavePickedUpByMob  byte = 3
+	DropLeaveExplode        byte = 4
+	DropLeavePickedUpByPet  byte = 5
+)
+
+// DropEncoder defines the interface for drop packet encoding
+type DropEncoder interface {
+	ObjectID() int32
+	IsMoney() bool
+	Info() int32
+	OwnerID() uint
+	OwnType() byte
+	Position() (x, y uint16)
+	SourceID() int32
+	SourcePosition() (x, y uint16)
+	ItemExpireAt() *time.Time
+	IsPlayerDrop() bool
+}
+
+// DropEnterField sends a packet to show a drop on the client
+func DropEnterField(drop DropEncoder, enterType byte, delay int16) protocol.Packet {
+	p := protocol.NewWithOpcode(SendDropEnterField)
+
+	p.WriteByte(enterType)
+	p.WriteInt(drop.ObjectID())
+	p.WriteBool(drop.IsMoney())
+	p.WriteInt(drop.Info()) // Meso amount or item ID
+	p.WriteInt(int32(drop.OwnerID()))
+	p.WriteByte(drop.OwnType())
+
+	x, y := drop.Position()
+	p.WriteShort(x)
+	p.WriteShort(y)
+	p.WriteInt(drop.SourceID())
+
+	// Drops already on the ground don't animate from their source
+	if enterType != DropEnterOnFoothold {
+		sx, sy := drop.SourcePosition()
+		p.WriteShort(sx)
+		p.WriteShort(sy)
+		p.WriteShort(uint16(delay))
+	}
+
+	if !drop.IsMoney() {
+		writeExpireTime(&p, drop.ItemExpireAt())
+	}
+
+	p.WriteBool(!drop.IsPlayerDrop()) // bByPet: pets may only loot mob drops
+	p.WriteBool(false)
+
+	return p
+}
+
+// DropLeaveField sends a packet to remove a drop. pickerID is the character
+// (or mob) that picked it up, and is only sent for pickup leave types.
+func DropLeaveField(objectID int32, leaveType byte, pickerID int32) protocol.Packet {
+	p := protocol.NewWithOpcode(SendDropLeaveField)
+
+	p.WriteByte(leaveType)
+	p.WriteInt(objectID)
+
+	switch leaveType {
+	case DropLeavePickedUpByUser, DropLeavePickedUpByMob:
+		p.WriteInt(pickerID)
+	case DropLeavePickedUpByPet:
+		p.WriteInt(pickerID)
+		p.WriteInt(0) // Pet index
+	case DropLeaveExplode:
+		p.WriteShort(0) // Delay
+	}
+
+	return p
+}
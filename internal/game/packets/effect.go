@@ -43,6 +43,44 @@ func UserEffectLevelUp() protocol.Packet {
 	return p
 }
 
+// UserEffectRemoteLevelUp shows another user's level up effect
+func UserEffectRemoteLevelUp(characterID uint) protocol.Packet {
+	p := protocol.NewWithOpcode(SendUserEffectRemote)
+	p.WriteInt(int32(characterID))
+	p.WriteByte(EffectLevelUp)
+	return p
+}
+
+// UserEffectRemoteSkillUse shows another user's skill use effect
+func UserEffectRemoteSkillUse(characterID uint, skillID int32, charLevel, skillLevel byte) protocol.Packet {
+	p := protocol.NewWithOpcode(SendUserEffectRemote)
+	p.WriteInt(int32(characterID))
+	p.WriteByte(EffectSkillUse)
+	p.WriteInt(skillID)
+	p.WriteByte(charLevel)
+	p.WriteByte(skillLevel)
+	return p
+}
+
+// UserEffectSkillAffected shows the local user being buffed by a party member's skill
+func UserEffectSkillAffected(skillID int32, skillLevel byte) protocol.Packet {
+	p := protocol.NewWithOpcode(SendUserEffectLocal)
+	p.WriteByte(EffectSkillAffected)
+	p.WriteInt(skillID)
+	p.WriteByte(skillLevel)
+	return p
+}
+
+// UserEffectRemoteSkillAffected shows another user being buffed by a party member's skill
+func UserEffectRemoteSkillAffected(characterID uint, skillID int32, skillLevel byte) protocol.Packet {
+	p := protocol.NewWithOpcode(SendUserEffectRemote)
+	p.WriteInt(int32(characterID))
+	p.WriteByte(EffectSkillAffected)
+	p.WriteInt(skillID)
+	p.WriteByte(skillLevel)
+	return p
+}
+
 // UserEffectJobChanged sends a job change effect to the local user
 func UserEffectJobChanged() protocol.Packet {
 	p := protocol.NewWithOpcode(SendUserEffectLocal)
@@ -57,6 +95,16 @@ func UserEffectQuestComplete() protocol.Packet {
 	return p
 }
 
+// UserEffectQuestItem shows the item gained/lost notice for a quest reward
+func UserEffectQuestItem(itemID int32, count int32) protocol.Packet {
+	p := protocol.NewWithOpcode(SendUserEffectLocal)
+	p.WriteByte(EffectQuest)
+	p.WriteByte(1) // number of entries
+	p.WriteInt(itemID)
+	p.WriteInt(count)
+	return p
+}
+
 // UserEffectAvatarOriented sends an avatar-oriented effect (UI tutorial images, etc.)
 func UserEffectAvatarOriented(effectPath string) protocol.Packet {
 	p := protocol.NewWithOpcode(SendUserEffectLocal)
@@ -100,3 +148,32 @@ func UserBalloonMsg(text string, width, duration int16) protocol.Packet {
 	p.WriteBool(true)             // avatar oriented (if false: needs int x, int y)
 	return p
 }
+
+// UserItemUpgradeEffect shows the result of a scroll being used on an equip
+func UserItemUpgradeEffect(characterID uint, success, destroyed, whiteScroll bool) protocol.Packet {
+	p := protocol.NewWithOpcode(SendUserItemUpgradeEffect)
+	p.WriteInt(int32(characterID))
+	p.WriteBool(success)     // bSuccess
+	p.WriteBool(destroyed)   // bCursed
+	p.WriteBool(false)       // bEnchantSkill
+	p.WriteInt(0)            // nEnchantCategory
+	p.WriteBool(whiteScroll) // bWhiteScroll
+	p.WriteBool(false)       // bRecoverable
+	return p
+}
+
+// UserItemReleaseEffect shows a magnifying glass revealing an equip's potential
+func UserItemReleaseEffect(characterID uint, equipSlot int16) protocol.Packet {
+	p := protocol.NewWithOpcode(SendUserItemReleaseEffect)
+	p.WriteInt(int32(characterID))
+	p.WriteShort(uint16(equipSlot)) // nEPOS
+	return p
+}
+
+// UserItemUnreleaseEffect shows a miracle cube being used on an equip
+func UserItemUnreleaseEffect(characterID uint, success bool) protocol.Packet {
+	p := protocol.NewWithOpcode(SendUserItemUnreleaseEffect)
+	p.WriteInt(int32(characterID))
+	p.WriteBool(success)
+	return p
+}
@@ -0,0 +1,130 @@
+package packets
+
+import "github.com/Jinw00Arise/Jinwoo/internal/protocol"
+
+const (
+	// DefaultFriendMax is the buddy list capacity of a new character
+	DefaultFriendMax = 20
+
+	// FriendChannelOffline is the channel shown for offline buddies
+	FriendChannelOffline int32 = -1
+)
+
+// Friend request types sent by the client
+const (
+	FriendRequestLoad   byte = 0
+	FriendRequestSet    byte = 1 // Add a buddy or change their group
+	FriendRequestAccept byte = 2
+	FriendRequestDelete byte = 3
+)
+
+// Friend result types for SendFriendResult
+const (
+	FriendResultLoadDone        byte = 7
+	FriendResultNotifyChange    byte = 8
+	FriendResultInvite          byte = 9
+	FriendResultSetDone         byte = 10
+	FriendResultFullMe          byte = 11
+	FriendResultFullOther       byte = 12
+	FriendResultAlreadySet      byte = 13
+	FriendResultMaster          byte = 14
+	FriendResultUnknownUser     byte = 15
+	FriendResultUnknown         byte = 16
+	FriendResultAcceptUnknown   byte = 17
+	FriendResultDeleteDone      byte = 18
+	FriendResultDeleteUnknown   byte = 19
+	FriendResultNotify          byte = 20
+	FriendResultIncMaxCountDone byte = 21
+)
+
+// Friend entry flags
+const (
+	FriendFlagNormal  byte = 0
+	FriendFlagRequest byte = 1 // Request sent, not accepted yet
+)
+
+// Group message types, shared by buddy, party and guild chat
+const (
+	GroupMessageBuddy    byte = 0
+	GroupMessageParty    byte = 1
+	GroupMessageGuild    byte = 2
+	GroupMessageAlliance byte = 3
+)
+
+// Friend is one entry of the buddy window
+type Friend struct {
+	CharacterID uint
+	Name        string
+	Flag        byte
+	ChannelID   int32 // FriendChannelOffline while offline
+	Group       string
+	InShop      bool
+}
+
+func writeFriend(b *protocol.Builder, f Friend) {
+	b.Int(int32(f.CharacterID)).
+		FixedString(f.Name, 13).
+		Byte(f.Flag).
+		Int(f.ChannelID).
+		FixedString(f.Group, 17)
+}
+
+// FriendList sends the whole buddy list. result is FriendResultLoadDone,
+// FriendResultSetDone or FriendResultDeleteDone depending on what changed.
+func FriendList(result byte, friends []Friend) protocol.Packet {
+	b := protocol.NewBuilder(SendFriendResult).
+		Byte(result).
+		Byte(byte(len(friends)))
+	for _, f := range friends {
+		writeFriend(b, f)
+	}
+	for _, f := range friends {
+		inShop := int32(0)
+		if f.InShop {
+			inShop = 1
+		}
+		b.Int(inShop)
+	}
+	return b.Build()
+}
+
+// FriendInvite asks the target to accept a buddy request
+func FriendInvite(from Friend, level, job int32) protocol.Packet {
+	b := protocol.NewBuilder(SendFriendResult).
+		Byte(FriendResultInvite).
+		Int(int32(from.CharacterID)).
+		String(from.Name).
+		Int(level).
+		Int(job)
+	writeFriend(b, from)
+	return b.Bool(from.InShop).Build()
+}
+
+// FriendNotify updates a buddy's online state and channel
+func FriendNotify(characterID uint, channelID int32, inShop bool) protocol.Packet {
+	return protocol.NewBuilder(SendFriendResult).
+		Byte(FriendResultNotify).
+		Int(int32(characterID)).
+		Bool(inShop).
+		Int(channelID).
+		Build()
+}
+
+// FriendMessage sends one of the result types that carries no data
+func FriendMessage(result byte) protocol.Packet {
+	b := protocol.NewBuilder(SendFriendResult).
+		Byte(result)
+	if result == FriendResultUnknown || result == FriendResultAcceptUnknown || result == FriendResultDeleteUnknown {
+		b.Byte(0) // No custom message
+	}
+	return b.Build()
+}
+
+// GroupMessage delivers a buddy, party or guild chat line
+func GroupMessage(messageType byte, from, text string) protocol.Packet {
+	return protocol.NewBuilder(SendGroupMessage).
+		Byte(messageType).
+		String(from).
+		String(text).
+		Build()
+}
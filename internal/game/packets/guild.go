@@ -0,0 +1,301 @@
+package packets
+
+import "github.com/Jinw00Arise/Jinwoo/internal/protocol"
+
+// Guild request types sent by the client
+const (
+	GuildRequestLoad           byte = 0
+	GuildRequestCheckGuildName byte = 2 // Guild name entered after the NPC opened the dialog
+	GuildRequestInvite         byte = 5
+	GuildRequestJoin           byte = 6 // Invite accepted
+	GuildRequestWithdraw       byte = 7
+	GuildRequestKick           byte = 8
+	GuildRequestSetGradeName   byte = 13
+	GuildRequestSetMemberGrade byte = 14
+	GuildRequestSetMark        byte = 15 // Emblem chosen after the NPC opened the dialog
+	GuildRequestSetNotice      byte = 16
+)
+
+// Guild result types for SendGuildResult. The client answers invites with the
+// invite result types through RecvGuildResult.
+const (
+	GuildResultInputGuildName              byte = 1
+	GuildResultInvite                      byte = 5
+	GuildResultInputMark                   byte = 17
+	GuildResultLoadDone                    byte = 26
+	GuildResultCheckGuildNameAlreadyUsed   byte = 29
+	GuildResultCreateAlreadyJoined         byte = 34
+	GuildResultCreateGuildNameAlreadyExist byte = 35
+	GuildResultCreateBeginner              byte = 36
+	GuildResultCreateUnknown               byte = 39
+	GuildResultJoinDone                    byte = 40
+	GuildResultJoinAlreadyJoined           byte = 41
+	GuildResultJoinAlreadyFull             byte = 42
+	GuildResultJoinUnknownUser             byte = 43
+	GuildResultJoinUnknown                 byte = 44
+	GuildResultWithdrawDone                byte = 45
+	GuildResultWithdrawNotJoined           byte = 46
+	GuildResultWithdrawUnknown             byte = 47
+	GuildResultKickDone                    byte = 48
+	GuildResultKickNotJoined               byte = 49
+	GuildResultKickUnknown                 byte = 50
+	GuildResultRemoveDone                  byte = 51
+	GuildResultRemoveNotExist              byte = 52
+	GuildResultRemoveUnknown               byte = 53
+	GuildResultInviteBlockedUser           byte = 54
+	GuildResultInviteAlreadyInvited        byte = 55
+	GuildResultInviteRejected              byte = 56
+	GuildResultIncMaxMemberNumDone         byte = 59
+	GuildResultIncMaxMemberNumUnknown      byte = 60
+	GuildResultChangeLevelOrJob            byte = 61
+	GuildResultNotifyLoginOrLogout         byte = 62
+	GuildResultSetGradeNameDone            byte = 63
+	GuildResultSetGradeNameUnknown         byte = 64
+	GuildResultSetMemberGradeDone          byte = 65
+	GuildResultSetMemberGradeUnknown       byte = 66
+	GuildResultSetMarkDone                 byte = 68
+	GuildResultSetMarkUnknown              byte = 69
+	GuildResultSetNoticeDone               byte = 70
+)
+
+// GuildMark is a guild emblem
+type GuildMark struct {
+	Bg      int16
+	BgColor byte
+	Mark    int16
+	Color   byte
+}
+
+// GuildMember is one row of the guild window
+type GuildMember struct {
+	CharacterID uint
+	Name        string
+	Job         int16
+	Level       int16
+	Grade       byte
+	Online      bool
+}
+
+// GuildData is the full guild window state
+type GuildData struct {
+	ID         uint
+	Name       string
+	GradeNames [5]string
+	Members    []GuildMember
+	Capacity   int32
+	Mark       GuildMark
+	Notice     string
+	Points     int32
+}
+
+// WriteGuildMark writes an emblem as it appears in user packets
+func WriteGuildMark(p *protocol.Packet, mark GuildMark) {
+	p.WriteShort(uint16(mark.Bg))
+	p.WriteByte(mark.BgColor)
+	p.WriteShort(uint16(mark.Mark))
+	p.WriteByte(mark.Color)
+}
+
+func writeGuildMark(b *protocol.Builder, mark GuildMark) {
+	b.Short(uint16(mark.Bg)).
+		Byte(mark.BgColor).
+		Short(uint16(mark.Mark)).
+		Byte(mark.Color)
+}
+
+func writeGuildMember(b *protocol.Builder, m GuildMember) {
+	online := int32(0)
+	if m.Online {
+		online = 1
+	}
+	b.FixedString(m.Name, 13).
+		Int(int32(m.Job)).
+		Int(int32(m.Level)).
+		Int(int32(m.Grade)).
+		Int(online).
+		Int(0). // nCommitment
+		Int(0)  // nAllianceGrade
+}
+
+// GuildLoaded sends the guild window. A nil guild clears it.
+func GuildLoaded(data *GuildData) protocol.Packet {
+	b := protocol.NewBuilder(SendGuildResult).
+		Byte(GuildResultLoadDone).
+		Bool(data != nil)
+	if data == nil {
+		return b.Build()
+	}
+
+	b.Int(int32(data.ID)).String(data.Name)
+	for _, name := range data.GradeNames {
+		b.String(name)
+	}
+	b.Byte(byte(len(data.Members)))
+	for _, m := range data.Members {
+		b.Int(int32(m.CharacterID))
+	}
+	for _, m := range data.Members {
+		writeGuildMember(b, m)
+	}
+	b.Int(data.Capacity)
+	writeGuildMark(b, data.Mark)
+	return b.String(data.Notice).
+		Int(data.Points).
+		Int(0).   // nAllianceID
+		Byte(1).  // nLevel
+		Short(0). // Guild skills
+		Build()
+}
+
+// GuildInputName opens the guild name dialog
+func GuildInputName() protocol.Packet {
+	return GuildMessage(GuildResultInputGuildName)
+}
+
+// GuildInputMark opens the emblem dialog
+func GuildInputMark() protocol.Packet {
+	return GuildMessage(GuildResultInputMark)
+}
+
+// GuildInvite asks the target to join a guild
+func GuildInvite(guildID uint, inviterName string, level, job int32) protocol.Packet {
+	return protocol.NewBuilder(SendGuildResult).
+		Byte(GuildResultInvite).
+		Int(int32(guildID)).
+		String(inviterName).
+		Int(level).
+		Int(job).
+		Build()
+}
+
+// GuildJoined adds a member to the guild window of every member
+func GuildJoined(guildID uint, member GuildMember) protocol.Packet {
+	b := protocol.NewBuilder(SendGuildResult).
+		Byte(GuildResultJoinDone).
+		Int(int32(guildID)).
+		Int(int32(member.CharacterID))
+	writeGuildMember(b, member)
+	return b.Build()
+}
+
+// GuildWithdrawn removes a member who left or was expelled
+func GuildWithdrawn(guildID, charID uint, name string, expelled bool) protocol.Packet {
+	result := GuildResultWithdrawDone
+	if expelled {
+		result = GuildResultKickDone
+	}
+	return protocol.NewBuilder(SendGuildResult).
+		Byte(result).
+		Int(int32(guildID)).
+		Int(int32(charID)).
+		String(name).
+		Build()
+}
+
+// GuildRemoved tells members their guild was disbanded
+func GuildRemoved(guildID uint) protocol.Packet {
+	return protocol.NewBuilder(SendGuildResult).
+		Byte(GuildResultRemoveDone).
+		Int(int32(guildID)).
+		Build()
+}
+
+// GuildCapacityChanged updates how many members fit in the guild
+func GuildCapacityChanged(guildID uint, capacity int32) protocol.Packet {
+	return protocol.NewBuilder(SendGuildResult).
+		Byte(GuildResultIncMaxMemberNumDone).
+		Int(int32(guildID)).
+		Byte(byte(capacity)).
+		Build()
+}
+
+// GuildMemberChanged updates a member's level and job
+func GuildMemberChanged(guildID, charID uint, level, job int16) protocol.Packet {
+	return protocol.NewBuilder(SendGuildResult).
+		Byte(GuildResultChangeLevelOrJob).
+		Int(int32(guildID)).
+		Int(int32(charID)).
+		Int(int32(level)).
+		Int(int32(job)).
+		Build()
+}
+
+// GuildMemberOnline updates a member's online state
+func GuildMemberOnline(guildID, charID uint, online bool) protocol.Packet {
+	return protocol.NewBuilder(SendGuildResult).
+		Byte(GuildResultNotifyLoginOrLogout).
+		Int(int32(guildID)).
+		Int(int32(charID)).
+		Bool(online).
+		Build()
+}
+
+// GuildGradeNamesChanged updates the rank titles
+func GuildGradeNamesChanged(guildID uint, names [5]string) protocol.Packet {
+	b := protocol.NewBuilder(SendGuildResult).
+		Byte(GuildResultSetGradeNameDone).
+		Int(int32(guildID))
+	for _, name := range names {
+		b.String(name)
+	}
+	return b.Build()
+}
+
+// GuildMemberGradeChanged updates a member's rank
+func GuildMemberGradeChanged(guildID, charID uint, grade byte) protocol.Packet {
+	return protocol.NewBuilder(SendGuildResult).
+		Byte(GuildResultSetMemberGradeDone).
+		Int(int32(guildID)).
+		Int(int32(charID)).
+		Byte(grade).
+		Build()
+}
+
+// GuildMarkChanged updates the emblem in the guild window
+func GuildMarkChanged(guildID uint, mark GuildMark) protocol.Packet {
+	b := protocol.NewBuilder(SendGuildResult).
+		Byte(GuildResultSetMarkDone).
+		Int(int32(guildID))
+	writeGuildMark(b, mark)
+	return b.Build()
+}
+
+// GuildNoticeChanged updates the guild notice
+func GuildNoticeChanged(guildID uint, notice string) protocol.Packet {
+	return protocol.NewBuilder(SendGuildResult).
+		Byte(GuildResultSetNoticeDone).
+		Int(int32(guildID)).
+		String(notice).
+		Build()
+}
+
+// GuildMessage sends one of the result types that carries no data
+func GuildMessage(result byte) protocol.Packet {
+	return protocol.NewBuilder(SendGuildResult).
+		Byte(result).
+		Build()
+}
+
+// GuildMessageName sends a result type about another character, such as a declined invite
+func GuildMessageName(result byte, name string) protocol.Packet {
+	return protocol.NewBuilder(SendGuildResult).
+		Byte(result).
+		String(name).
+		Build()
+}
+
+// UserGuildNameChanged shows a character's new guild name to others on the field
+func UserGuildNameChanged(charID uint, name string) protocol.Packet {
+	return protocol.NewBuilder(SendUserGuildNameChanged).
+		Int(int32(charID)).
+		String(name).
+		Build()
+}
+
+// UserGuildMarkChanged shows a character's new guild emblem to others on the field
+func UserGuildMarkChanged(charID uint, mark GuildMark) protocol.Packet {
+	b := protocol.NewBuilder(SendUserGuildMarkChanged).
+		Int(int32(charID))
+	writeGuildMark(b, mark)
+	return b.Build()
+}
@@ -0,0 +1,83 @@
+package packets
+
+import (
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+// InventoryOperationType identifies a single change in an InventoryOperation packet
+type InventoryOperationType byte
+
+const (
+	InventoryOpAdd      InventoryOperationType = 0
+	InventoryOpQuantity InventoryOperationType = 1
+	InventoryOpMove     InventoryOperationType = 2
+	InventoryOpRemove   InventoryOperationType = 3
+	InventoryOpItemExp  InventoryOperationType = 4
+)
+
+// InventoryOperation describes one change to a character's inventory
+type InventoryOperation struct {
+	Type     InventoryOperationType
+	InvType  models.InventoryType
+	Slot     int16
+	NewSlot  int16 // InventoryOpMove
+	Quantity int16 // InventoryOpQuantity
+	Item     *models.CharacterItem
+}
+
+// InventoryOperationPacket builds an InventoryOperation packet.
+// If exclRequest is true, the client will accept input after processing.
+func InventoryOperationPacket(exclRequest bool, ops []InventoryOperation) protocol.Packet {
+	p := protocol.NewWithOpcode(SendInventoryOperation)
+	p.WriteBool(exclRequest)
+	p.WriteByte(byte(len(ops)))
+
+	// Moving items in or out of equipped slots requires the client to refresh the avatar
+	var movementInfo byte
+	for _, op := range ops {
+		p.WriteByte(byte(op.Type))
+		p.WriteByte(clientInventoryType(op.InvType))
+		p.WriteShort(uint16(op.Slot))
+
+		switch op.Type {
+		case InventoryOpAdd:
+			EncodeItem(&p, op.Item)
+		case InventoryOpQuantity:
+			p.WriteShort(uint16(op.Quantity))
+		case InventoryOpMove:
+			p.WriteShort(uint16(op.NewSlot))
+			if op.Slot < 0 {
+				movementInfo = 1
+			} else if op.NewSlot < 0 {
+				movementInfo = 2
+			}
+		case InventoryOpRemove:
+			if op.Slot < 0 {
+				movementInfo = 2
+			}
+		case InventoryOpItemExp:
+			p.WriteInt(op.Item.Exp)
+		}
+	}
+
+	if movementInfo != 0 {
+		p.WriteByte(movementInfo)
+	}
+
+	return p
+}
+
+// clientInventoryType maps a stored inventory type to the client's tab index.
+// Equipped items live in the equip tab with a negative slot.
+func clientInventoryType(invType models.InventoryType) byte {
+	if invType == models.InvEquipped {
+		return 1
+	}
+	return byte(invType) - 1
+}
+
+// InventoryTypeFromClient maps a client tab index to the stored inventory type
+func InventoryTypeFromClient(invType byte) models.InventoryType {
+	return models.InventoryType(invType + 1)
+}
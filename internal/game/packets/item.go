@@ -0,0 +1,132 @@
+package packets
+
+import (
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+	"github.com/Jinw00Arise/Jinwoo/internal/utils"
+)
+
+// EncodeItem writes a GW_ItemSlotBase structure for the given item
+func EncodeItem(p *protocol.Packet, it *models.CharacterItem) {
+	itemType := utils.GetItemTypeByItemID(it.ItemID)
+	p.WriteByte(byte(itemType))
+
+	p.WriteInt(it.ItemID)
+
+	isCash := it.Cash
+	if isCash {
+		p.WriteByte(1)
+		p.WriteLong(uint64(it.ItemSN))
+	} else {
+		p.WriteByte(0)
+	}
+
+	writeExpireTime(p, it.ExpireAt)
+
+	switch itemType {
+	case utils.ItemTypeEquip:
+		encodeEquipData(p, it)
+	case utils.ItemTypePet:
+		encodePetData(p, it)
+	default:
+		p.WriteShort(uint16(it.Quantity))
+		p.WriteString(it.Owner)
+		p.WriteShort(uint16(it.Attribute))
+
+		if utils.IsRechargeableItem(it.ItemID) {
+			p.WriteLong(uint64(it.ItemSN))
+		}
+	}
+}
+
+func encodeEquipData(p *protocol.Packet, it *models.CharacterItem) {
+	p.WriteByte(it.RUC)
+	p.WriteByte(it.CUC)
+
+	p.WriteShort(uint16(it.IncStr))
+	p.WriteShort(uint16(it.IncDex))
+	p.WriteShort(uint16(it.IncInt))
+	p.WriteShort(uint16(it.IncLuk))
+	p.WriteShort(uint16(it.IncMaxHP))
+	p.WriteShort(uint16(it.IncMaxMP))
+	p.WriteShort(uint16(it.IncPAD))
+	p.WriteShort(uint16(it.IncMAD))
+	p.WriteShort(uint16(it.IncPDD))
+	p.WriteShort(uint16(it.IncMDD))
+	p.WriteShort(uint16(it.IncACC))
+	p.WriteShort(uint16(it.IncEVA))
+	p.WriteShort(uint16(it.IncCraft))
+	p.WriteShort(uint16(it.IncSpeed))
+	p.WriteShort(uint16(it.IncJump))
+
+	p.WriteString(it.Owner)
+	p.WriteShort(uint16(it.Attribute))
+
+	p.WriteByte(it.LevelUpType)
+	p.WriteByte(it.Level)
+	p.WriteInt(it.Exp)
+	p.WriteInt(it.Durability)
+
+	p.WriteInt(it.IUC)
+	p.WriteByte(it.Grade)
+	p.WriteByte(it.CHUC)
+
+	p.WriteShort(uint16(it.Option1))
+	p.WriteShort(uint16(it.Option2))
+	p.WriteShort(uint16(it.Option3))
+	p.WriteShort(uint16(it.Socket1))
+	p.WriteShort(uint16(it.Socket2))
+
+	if !it.Cash {
+		p.WriteLong(uint64(it.ItemSN))
+	}
+
+	writeFTZero(p)
+	p.WriteInt(0)
+}
+
+func encodePetData(p *protocol.Packet, it *models.CharacterItem) {
+	p.WriteStringWithLength(it.PetName, 13)
+	p.WriteByte(it.PetLevel)
+	p.WriteShort(uint16(it.PetTameness))
+	p.WriteByte(it.PetFullness)
+	writeExpireTime(p, it.ExpireAt)
+	p.WriteShort(uint16(it.PetAttribute))
+	p.WriteShort(uint16(it.PetSkill))
+	p.WriteInt(it.RemainLife)
+	p.WriteShort(uint16(it.Attribute))
+}
+
+// writeExpireTime writes an expiration time, handling nil pointers for permanent items
+func writeExpireTime(p *protocol.Packet, t *time.Time) {
+	if t == nil {
+		WriteFT(p, time.Time{})
+	} else {
+		WriteFT(p, *t)
+	}
+}
+
+func writeFTZero(p *protocol.Packet) {
+	p.WriteInt(0)
+	p.WriteInt(0)
+}
+
+const DefaultTime uint64 = 150842304000000000
+
+// WriteFT writes a time as a Windows FILETIME, using DefaultTime for the zero time
+func WriteFT(p *protocol.Packet, t time.Time) {
+	if t.IsZero() {
+		// Use DefaultTime for permanent/non-expiring items
+		p.WriteLong(DefaultTime)
+		return
+	}
+	// Convert Unix time to Windows FILETIME
+	// FILETIME epoch is January 1, 1601
+	// Unix epoch is January 1, 1970
+	// Difference is 116444736000000000 (100-nanosecond intervals)
+	const unixToFileTime = 116444736000000000
+	ft := uint64(t.UnixNano()/100) + unixToFileTime
+	p.WriteLong(ft)
+}
@@ -13,6 +13,7 @@ type MobEncoder interface {
 	MoveAction() byte
 	HP() int32
 	MaxHP() int32
+	TemporaryStats() MobTemporaryStats
 }
 
 // MobEnterField sends a packet to spawn a mob on the client
@@ -24,7 +25,7 @@ func MobEnterField(mob MobEncoder) protocol.Packet {
 	p.WriteInt(mob.TemplateID())
 
 	// Temp stat flags (buffs/debuffs on mob)
-	encodeMobTemporaryStat(&p)
+	writeMobTemporaryStats(&p, mob.TemporaryStats())
 
 	// Position
 	p.WriteShort(mob.GetX())
@@ -77,7 +78,7 @@ func MobChangeController(mob MobEncoder, level byte, controller bool) protocol.P
 		p.WriteInt(mob.TemplateID())
 
 		// Temp stat flags
-		encodeMobTemporaryStat(&p)
+		writeMobTemporaryStats(&p, mob.TemporaryStats())
 
 		// Position
 		p.WriteShort(mob.GetX())
@@ -97,25 +98,22 @@ func MobChangeController(mob MobEncoder, level byte, controller bool) protocol.P
 	return p
 }
 
-// MobHP sends a mob HP indicator packet (for boss HP bars, etc.)
-func MobHP(objectID int32, hpPercent byte) protocol.Packet {
-	p := protocol.NewWithOpcode(SendMobEnterField)
-	// This is actually a different packet but uses similar structure
-	// HP indicator is typically shown via ShowMobHP packet
+// MobCtrlAck acknowledges a controller's mob movement
+func MobCtrlAck(objectID int32, ctrlSN uint16, nextAttackPossible bool, mp int32, skillID, skillLevel byte) protocol.Packet {
+	p := protocol.NewWithOpcode(SendMobCtrlAck)
 	p.WriteInt(objectID)
-	p.WriteByte(hpPercent)
+	p.WriteShort(ctrlSN)
+	p.WriteBool(nextAttackPossible)
+	p.WriteShort(uint16(mp))
+	p.WriteByte(skillID)
+	p.WriteByte(skillLevel)
 	return p
 }
 
-// encodeMobTemporaryStat writes the mob buff/debuff flags
-// This is a simplified version - full implementation would include actual buffs
-func encodeMobTemporaryStat(p *protocol.Packet) {
-	// MobStat mask (128 bits = 4 ints for v83)
-	p.WriteInt(0) // Mask 1
-	p.WriteInt(0) // Mask 2
-	p.WriteInt(0) // Mask 3
-	p.WriteInt(0) // Mask 4
-
-	// If any mask bits are set, write corresponding stat values here
-	// For now, no buffs/debuffs
+// MobHP sends a mob HP indicator packet (the HP bar shown above a damaged mob)
+func MobHP(objectID int32, hpPercent byte) protocol.Packet {
+	p := protocol.NewWithOpcode(SendMobHPIndicator)
+	p.WriteInt(objectID)
+	p.WriteByte(hpPercent)
+	return p
 }
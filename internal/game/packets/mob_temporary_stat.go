@@ -0,0 +1,124 @@
+package packets
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+// MobTemporaryStat is a buff or debuff on a mob, numbered by its bit in the
+// client's 128-bit mob stat mask
+type MobTemporaryStat uint8
+
+const (
+	MobStatPAD MobTemporaryStat = iota
+	MobStatPDD
+	MobStatMAD
+	MobStatMDD
+	MobStatACC
+	MobStatEVA
+	MobStatSpeed
+	MobStatStun
+	MobStatFreeze
+	MobStatPoison
+	MobStatSeal
+	MobStatDarkness
+	MobStatPowerUp
+	MobStatMagicUp
+	MobStatPGuardUp
+	MobStatMGuardUp
+	MobStatDoom
+	MobStatWeb
+	MobStatPImmune
+	MobStatMImmune
+	MobStatShowdown
+	MobStatHardSkin
+	MobStatAmbush
+	MobStatDamagedElemAttr
+	MobStatVenom
+	MobStatBlind
+	MobStatSealSkill
+)
+
+// MobTemporaryStats maps each active mob stat to its value
+type MobTemporaryStats map[MobTemporaryStat]TemporaryStatValue
+
+// IsMovementAffecting returns true if the stat changes how the mob moves
+func (s MobTemporaryStat) IsMovementAffecting() bool {
+	switch s {
+	case MobStatSpeed, MobStatStun, MobStatFreeze, MobStatDoom:
+		return true
+	}
+	return false
+}
+
+func sortedMobStats(stats MobTemporaryStats) []MobTemporaryStat {
+	keys := make([]MobTemporaryStat, 0, len(stats))
+	for s := range stats {
+		keys = append(keys, s)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func anyMobMovementAffecting(stats []MobTemporaryStat) bool {
+	for _, s := range stats {
+		if s.IsMovementAffecting() {
+			return true
+		}
+	}
+	return false
+}
+
+// writeMobTemporaryStats writes MobStat::DecodeTemporary
+func writeMobTemporaryStats(p *protocol.Packet, stats MobTemporaryStats) {
+	keys := sortedMobStats(stats)
+
+	var mask tempStatMask
+	for _, s := range keys {
+		mask.set(uint8(s))
+	}
+	mask.write(p)
+
+	now := time.Now()
+	for _, s := range keys {
+		v := stats[s]
+		p.WriteShort(uint16(v.Option))
+		p.WriteInt(v.Reason)
+		p.WriteShort(uint16(max(v.ExpireAt.Sub(now)/(500*time.Millisecond), 0))) // Remaining, in 500ms units
+	}
+}
+
+// MobStatSet shows new buffs/debuffs on a mob
+func MobStatSet(objectID int32, stats MobTemporaryStats) protocol.Packet {
+	p := protocol.NewWithOpcode(SendMobStatSet)
+
+	p.WriteInt(objectID)
+	writeMobTemporaryStats(&p, stats)
+	p.WriteShort(0) // tDelay
+	p.WriteByte(1)  // nCalcDamageStatIndex
+	if anyMobMovementAffecting(sortedMobStats(stats)) {
+		p.WriteByte(0)
+	}
+
+	return p
+}
+
+// MobStatReset removes ended buffs/debuffs from a mob
+func MobStatReset(objectID int32, stats []MobTemporaryStat) protocol.Packet {
+	p := protocol.NewWithOpcode(SendMobStatReset)
+
+	p.WriteInt(objectID)
+	var mask tempStatMask
+	for _, s := range stats {
+		mask.set(uint8(s))
+	}
+	mask.write(&p)
+	p.WriteByte(1) // nCalcDamageStatIndex
+	if anyMobMovementAffecting(stats) {
+		p.WriteByte(0)
+	}
+
+	return p
+}
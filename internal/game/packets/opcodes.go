@@ -2,79 +2,189 @@ package packets
 
 // Client -> Server opcodes
 const (
-	RecvMigrateIn                  uint16 = 20
-	RecvUserTransferFieldRequest   uint16 = 41
-	RecvUserMove                   uint16 = 44
-	RecvUserChat                   uint16 = 54
-	RecvUserScriptMessageAnswer    uint16 = 65  // Response to NPC dialog
-	RecvUserQuestRequest           uint16 = 108 // Quest actions (start, complete, forfeit)
-	RecvUserPortalScriptRequest    uint16 = 112
-	RecvUpdateGMBoard              uint16 = 192
-	RecvUpdateScreenSetting        uint16 = 218
-	RecvNpcMove                    uint16 = 241
-	RecvRequireFieldObstacleStatus uint16 = 251
-	RecvCancelInvitePartyMatch     uint16 = 267
+	RecvMigrateIn                       uint16 = 20
+	RecvUserTransferFieldRequest        uint16 = 41
+	RecvUserMove                        uint16 = 44
+	RecvUserMeleeAttack                 uint16 = 47
+	RecvUserShootAttack                 uint16 = 48
+	RecvUserMagicAttack                 uint16 = 49
+	RecvUserChat                        uint16 = 54
+	RecvUserSelectNpc                   uint16 = 63  // NPC click
+	RecvUserScriptMessageAnswer         uint16 = 65  // Response to NPC dialog
+	RecvUserChangeSlotPosition          uint16 = 77  // Inventory item move / equip / drop
+	RecvUserStatChangeItemUseRequest    uint16 = 78  // Potions and other consumables
+	RecvUserStatChangeItemCancelRequest uint16 = 79  // Cancel an item buff
+	RecvUserConsumeCashItemUseRequest   uint16 = 85  // Cash items such as miracle cubes
+	RecvUserPortalScrollUseRequest      uint16 = 92  // Return scrolls
+	RecvUserUpgradeItemUseRequest       uint16 = 93  // Equipment scrolls
+	RecvUserItemReleaseRequest          uint16 = 97  // Magnifying glass on hidden potential
+	RecvUserAbilityUpRequest            uint16 = 98  // Spend one AP
+	RecvUserAbilityMassUpRequest        uint16 = 99  // Auto-assign AP
+	RecvUserSkillUpRequest              uint16 = 102 // Spend one SP
+	RecvUserSkillUseRequest             uint16 = 103 // Buff / active skill use
+	RecvUserSkillCancelRequest          uint16 = 104 // Cancel a buff
+	RecvUserQuestRequest                uint16 = 108 // Quest actions (start, complete, forfeit)
+	RecvUserMacroSysDataModified        uint16 = 111 // Skill macros saved
+	RecvUserPortalScriptRequest         uint16 = 112
+	RecvGroupMessage                    uint16 = 140 // Buddy, party and guild chat
+	RecvWhisper                         uint16 = 141 // Whisper and /find
+	RecvPartyRequest                    uint16 = 145 // Party create, leave, invite, kick, leader change
+	RecvPartyResult                     uint16 = 146 // Answer to a party invite
+	RecvGuildRequest                    uint16 = 148 // Guild create, invite, leave, expel, ranks, notice, emblem
+	RecvGuildResult                     uint16 = 149 // Answer to a guild invite
+	RecvFriendRequest                   uint16 = 153 // Buddy list load, add, accept, delete
+	RecvUpdateGMBoard                   uint16 = 192
+	RecvUpdateScreenSetting             uint16 = 218
+	RecvMobMove                         uint16 = 227 // Mob movement from its controller
+	RecvNpcMove                         uint16 = 241
+	RecvDropPickUpRequest               uint16 = 246 // Item / meso pickup
+	RecvRequireFieldObstacleStatus      uint16 = 251
+	RecvCancelInvitePartyMatch          uint16 = 267
 )
 
 // Server -> Client opcodes
 const (
-	SendMigrateCommand      uint16 = 16
-	SendStatChanged         uint16 = 30 // Stat update / EnableActions
-	SendQuestResult         uint16 = 44 // Quest result responses
-	SendScriptMessage       uint16 = 363
-	SendSetField            uint16 = 141
-	SendMessage             uint16 = 146 // For quest-related messages (item gain, etc.)
-	SendUserEnterField      uint16 = 179
-	SendUserLeaveField      uint16 = 180
-	SendUserChat            uint16 = 181
-	SendUserMove            uint16 = 210
-	SendUserEffectLocal     uint16 = 233 // Local user effects (level up, avatar oriented, etc.)
-	SendUserBalloonMsg      uint16 = 245 // Balloon message above player head
-	SendMobEnterField       uint16 = 284 // Mob spawn
-	SendMobLeaveField       uint16 = 285 // Mob despawn
-	SendMobChangeController uint16 = 286 // Mob controller change
-	SendMobMove             uint16 = 287 // Mob movement
-	SendNpcEnterField       uint16 = 311
-	SendNpcLeaveField       uint16 = 312
-	SendNpcChangeController uint16 = 313
-	SendNpcMove             uint16 = 314
+	SendMigrateCommand          uint16 = 16
+	SendInventoryOperation      uint16 = 28
+	SendStatChanged             uint16 = 30 // Stat update / EnableActions
+	SendTemporaryStatSet        uint16 = 31 // Buffs / debuffs applied
+	SendTemporaryStatReset      uint16 = 32 // Buffs / debuffs ended
+	SendChangeSkillRecordResult uint16 = 35 // Skill level changes
+	SendQuestResult             uint16 = 44 // Quest result responses
+	SendPartyResult             uint16 = 62 // Party window updates and messages
+	SendFriendResult            uint16 = 65 // Buddy list updates and messages
+	SendGuildResult             uint16 = 67 // Guild window updates and messages
+	SendScriptMessage           uint16 = 363
+	SendMacroSysDataInit        uint16 = 140 // Skill macros
+	SendSetField                uint16 = 141
+	SendMessage                 uint16 = 146 // For quest-related messages (item gain, etc.)
+	SendGroupMessage            uint16 = 150 // Buddy, party and guild chat
+	SendWhisper                 uint16 = 151 // Whisper and /find results
+	SendUserEnterField          uint16 = 179
+	SendUserLeaveField          uint16 = 180
+	SendUserChat                uint16 = 181
+	SendUserItemUpgradeEffect   uint16 = 186 // Scroll success / failure effect
+	SendUserItemReleaseEffect   uint16 = 189 // Hidden potential revealed
+	SendUserItemUnreleaseEffect uint16 = 190 // Miracle cube used
+	SendUserMove                uint16 = 210
+	SendUserMeleeAttack         uint16 = 211 // Remote user attacks
+	SendUserShootAttack         uint16 = 212
+	SendUserMagicAttack         uint16 = 213
+	SendUserAvatarModified      uint16 = 223 // Remote user look change
+	SendUserEffectRemote        uint16 = 224 // Remote user effects (level up, skill use, etc.)
+	SendUserTemporaryStatSet    uint16 = 225 // Remote user buffs applied
+	SendUserTemporaryStatReset  uint16 = 226 // Remote user buffs ended
+	SendUserHP                  uint16 = 227 // Party member HP bar
+	SendUserGuildNameChanged    uint16 = 228 // Remote user guild name
+	SendUserGuildMarkChanged    uint16 = 229 // Remote user guild emblem
+	SendUserEffectLocal         uint16 = 233 // Local user effects (level up, avatar oriented, etc.)
+	SendUserBalloonMsg          uint16 = 245 // Balloon message above player head
+	SendMobEnterField           uint16 = 284 // Mob spawn
+	SendMobLeaveField           uint16 = 285 // Mob despawn
+	SendMobChangeController     uint16 = 286 // Mob controller change
+	SendMobMove                 uint16 = 287 // Mob movement
+	SendMobCtrlAck              uint16 = 288 // Mob movement acknowledgement to the controller
+	SendMobStatSet              uint16 = 290 // Mob buffs / debuffs applied
+	SendMobStatReset            uint16 = 291 // Mob buffs / debuffs ended
+	SendMobHPIndicator          uint16 = 298 // Mob HP bar above the mob
+	SendNpcEnterField           uint16 = 311
+	SendNpcLeaveField           uint16 = 312
+	SendNpcChangeController     uint16 = 313
+	SendNpcMove                 uint16 = 314
+	SendDropEnterField          uint16 = 322 // Drop spawn
+	SendDropLeaveField          uint16 = 324 // Drop pickup / expiry
 )
 
 var RecvOpcodeNames = map[uint16]string{
-	RecvMigrateIn:                  "MigrateIn",
-	RecvUserMove:                   "UserMove",
-	RecvUserChat:                   "UserChat",
-	RecvUserScriptMessageAnswer:    "UserScriptMessageAnswer",
-	RecvUserQuestRequest:           "UserQuestRequest",
-	RecvUserPortalScriptRequest:    "UserPortalScriptRequest",
-	RecvUpdateGMBoard:              "UpdateGMBoard",
-	RecvUpdateScreenSetting:        "UpdateScreenSetting",
-	RecvRequireFieldObstacleStatus: "RequireFieldObstacleStatus",
-	RecvCancelInvitePartyMatch:     "CancelInvitePartyMatch",
-	RecvNpcMove:                    "NpcMove",
+	RecvMigrateIn:                       "MigrateIn",
+	RecvUserMove:                        "UserMove",
+	RecvUserMeleeAttack:                 "UserMeleeAttack",
+	RecvUserShootAttack:                 "UserShootAttack",
+	RecvUserMagicAttack:                 "UserMagicAttack",
+	RecvUserChat:                        "UserChat",
+	RecvUserSelectNpc:                   "UserSelectNpc",
+	RecvUserScriptMessageAnswer:         "UserScriptMessageAnswer",
+	RecvUserChangeSlotPosition:          "UserChangeSlotPosition",
+	RecvUserStatChangeItemUseRequest:    "UserStatChangeItemUseRequest",
+	RecvUserStatChangeItemCancelRequest: "UserStatChangeItemCancelRequest",
+	RecvUserConsumeCashItemUseRequest:   "UserConsumeCashItemUseRequest",
+	RecvUserPortalScrollUseRequest:      "UserPortalScrollUseRequest",
+	RecvUserUpgradeItemUseRequest:       "UserUpgradeItemUseRequest",
+	RecvUserItemReleaseRequest:          "UserItemReleaseRequest",
+	RecvUserAbilityUpRequest:            "UserAbilityUpRequest",
+	RecvUserAbilityMassUpRequest:        "UserAbilityMassUpRequest",
+	RecvUserSkillUpRequest:              "UserSkillUpRequest",
+	RecvUserSkillUseRequest:             "UserSkillUseRequest",
+	RecvUserSkillCancelRequest:          "UserSkillCancelRequest",
+	RecvUserQuestRequest:                "UserQuestRequest",
+	RecvUserMacroSysDataModified:        "UserMacroSysDataModified",
+	RecvUserPortalScriptRequest:         "UserPortalScriptRequest",
+	RecvGroupMessage:                    "GroupMessage",
+	RecvWhisper:                         "Whisper",
+	RecvPartyRequest:                    "PartyRequest",
+	RecvPartyResult:                     "PartyResult",
+	RecvGuildRequest:                    "GuildRequest",
+	RecvGuildResult:                     "GuildResult",
+	RecvFriendRequest:                   "FriendRequest",
+	RecvUpdateGMBoard:                   "UpdateGMBoard",
+	RecvUpdateScreenSetting:             "UpdateScreenSetting",
+	RecvMobMove:                         "MobMove",
+	RecvRequireFieldObstacleStatus:      "RequireFieldObstacleStatus",
+	RecvCancelInvitePartyMatch:          "CancelInvitePartyMatch",
+	RecvNpcMove:                         "NpcMove",
+	RecvDropPickUpRequest:               "DropPickUpRequest",
 }
 
 var SendOpcodeNames = map[uint16]string{
-	SendMigrateCommand:      "MigrateCommand",
-	SendStatChanged:         "StatChanged",
-	SendQuestResult:         "QuestResult",
-	SendScriptMessage:       "ScriptMessage",
-	SendSetField:            "SetField",
-	SendMessage:             "Message",
-	SendUserEnterField:      "UserEnterField",
-	SendUserLeaveField:      "UserLeaveField",
-	SendUserChat:            "UserChat",
-	SendUserMove:            "UserMove",
-	SendUserEffectLocal:     "UserEffectLocal",
-	SendUserBalloonMsg:      "UserBalloonMsg",
-	SendMobEnterField:       "MobEnterField",
-	SendMobLeaveField:       "MobLeaveField",
-	SendMobChangeController: "MobChangeController",
-	SendMobMove:             "MobMove",
-	SendNpcEnterField:       "NpcEnterField",
-	SendNpcLeaveField:       "NpcLeaveField",
-	SendNpcChangeController: "NpcChangeController",
-	SendNpcMove:             "NpcMove",
+	SendMigrateCommand:          "MigrateCommand",
+	SendInventoryOperation:      "InventoryOperation",
+	SendStatChanged:             "StatChanged",
+	SendTemporaryStatSet:        "TemporaryStatSet",
+	SendTemporaryStatReset:      "TemporaryStatReset",
+	SendChangeSkillRecordResult: "ChangeSkillRecordResult",
+	SendQuestResult:             "QuestResult",
+	SendPartyResult:             "PartyResult",
+	SendFriendResult:            "FriendResult",
+	SendGuildResult:             "GuildResult",
+	SendScriptMessage:           "ScriptMessage",
+	SendMacroSysDataInit:        "MacroSysDataInit",
+	SendSetField:                "SetField",
+	SendMessage:                 "Message",
+	SendGroupMessage:            "GroupMessage",
+	SendWhisper:                 "Whisper",
+	SendUserEnterField:          "UserEnterField",
+	SendUserLeaveField:          "UserLeaveField",
+	SendUserChat:                "UserChat",
+	SendUserItemUpgradeEffect:   "UserItemUpgradeEffect",
+	SendUserItemReleaseEffect:   "UserItemReleaseEffect",
+	SendUserItemUnreleaseEffect: "UserItemUnreleaseEffect",
+	SendUserMove:                "UserMove",
+	SendUserMeleeAttack:         "UserMeleeAttack",
+	SendUserShootAttack:         "UserShootAttack",
+	SendUserMagicAttack:         "UserMagicAttack",
+	SendUserAvatarModified:      "UserAvatarModified",
+	SendUserEffectRemote:        "UserEffectRemote",
+	SendUserTemporaryStatSet:    "UserTemporaryStatSet",
+	SendUserTemporaryStatReset:  "UserTemporaryStatReset",
+	SendUserHP:                  "UserHP",
+	SendUserGuildNameChanged:    "UserGuildNameChanged",
+	SendUserGuildMarkChanged:    "UserGuildMarkChanged",
+	SendUserEffectLocal:         "UserEffectLocal",
+	SendUserBalloonMsg:          "UserBalloonMsg",
+	SendMobEnterField:           "MobEnterField",
+	SendMobLeaveField:           "MobLeaveField",
+	SendMobChangeController:     "MobChangeController",
+	SendMobMove:                 "MobMove",
+	SendMobCtrlAck:              "MobCtrlAck",
+	SendMobStatSet:              "MobStatSet",
+	SendMobStatReset:            "MobStatReset",
+	SendMobHPIndicator:          "MobHPIndicator",
+	SendNpcEnterField:           "NpcEnterField",
+	SendNpcLeaveField:           "NpcLeaveField",
+	SendNpcChangeController:     "NpcChangeController",
+	SendNpcMove:                 "NpcMove",
+	SendDropEnterField:          "DropEnterField",
+	SendDropLeaveField:          "DropLeaveField",
 }
 
 var IgnoredRecvOpcodes = map[uint16]struct{}{
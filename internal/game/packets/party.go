@@ -0,0 +1,221 @@
+package packets
+
+import "github.com/Jinw00Arise/Jinwoo/internal/protocol"
+
+const (
+	// MaxPartyMembers is how many characters fit in a party
+	MaxPartyMembers = 6
+
+	// PartyChannelOffline is the channel shown for offline party members
+	PartyChannelOffline int32 = -2
+
+	// partyNoField is the field ID sent for empty town portal slots
+	partyNoField int32 = 999999999
+)
+
+// Party request types sent by the client
+const (
+	PartyRequestCreate     byte = 1
+	PartyRequestWithdraw   byte = 2
+	PartyRequestJoin       byte = 3
+	PartyRequestInvite     byte = 4
+	PartyRequestKick       byte = 5
+	PartyRequestChangeBoss byte = 6
+)
+
+// Party result types for SendPartyResult. The client answers invites with the
+// invite result types through RecvPartyResult.
+const (
+	PartyResultInvite                 byte = 4
+	PartyResultLoadDone               byte = 7
+	PartyResultCreateDone             byte = 8
+	PartyResultCreateAlreadyJoined    byte = 9
+	PartyResultCreateBeginner         byte = 10
+	PartyResultCreateUnknown          byte = 11
+	PartyResultWithdrawDone           byte = 12
+	PartyResultWithdrawNotJoined      byte = 13
+	PartyResultJoinDone               byte = 15
+	PartyResultJoinAlreadyJoined      byte = 17
+	PartyResultJoinAlreadyFull        byte = 18
+	PartyResultJoinUnknownUser        byte = 20
+	PartyResultJoinUnknown            byte = 21
+	PartyResultInviteSent             byte = 22
+	PartyResultInviteBlocked          byte = 23
+	PartyResultInviteAlreadyInvited   byte = 24
+	PartyResultInviteRejected         byte = 26
+	PartyResultInviteAccepted         byte = 27
+	PartyResultKickUnknown            byte = 30
+	PartyResultChangeBossDone         byte = 31
+	PartyResultChangeBossNotSameField byte = 32
+	PartyResultChangeBossUnknown      byte = 35
+)
+
+// PartyMember is one slot of the party window
+type PartyMember struct {
+	CharacterID uint
+	Name        string
+	Job         int16
+	Level       int16
+	ChannelID   int32 // PartyChannelOffline while offline
+	MapID       int32
+}
+
+// PartyData is the full party window state
+type PartyData struct {
+	LeaderID uint
+	Members  []PartyMember
+}
+
+// PartyCreated tells the leader their new party was made
+func PartyCreated(partyID uint) protocol.Packet {
+	return protocol.NewBuilder(SendPartyResult).
+		Byte(PartyResultCreateDone).
+		Int(int32(partyID)).
+		Int(partyNoField). // dwTownID
+		Int(partyNoField). // dwFieldID
+		Int(0).            // nSkillID
+		Int(0).            // ptFieldPortal.x
+		Int(0).            // ptFieldPortal.y
+		Build()
+}
+
+// PartyInvite asks a character to join the inviter's party
+func PartyInvite(partyID uint, inviterName string, inviterLevel, inviterJob int16) protocol.Packet {
+	return protocol.NewBuilder(SendPartyResult).
+		Byte(PartyResultInvite).
+		Int(int32(partyID)).
+		String(inviterName).
+		Int(int32(inviterLevel)).
+		Int(int32(inviterJob)).
+		Byte(0). // nPartyOpt
+		Build()
+}
+
+// PartyLoaded refreshes the whole party window
+func PartyLoaded(partyID uint, data PartyData) protocol.Packet {
+	b := protocol.NewBuilder(SendPartyResult).
+		Byte(PartyResultLoadDone).
+		Int(int32(partyID))
+	writePartyData(b, data)
+	return b.Build()
+}
+
+// PartyJoined announces a new member to the party
+func PartyJoined(partyID uint, name string, data PartyData) protocol.Packet {
+	b := protocol.NewBuilder(SendPartyResult).
+		Byte(PartyResultJoinDone).
+		Int(int32(partyID)).
+		String(name)
+	writePartyData(b, data)
+	return b.Build()
+}
+
+// PartyWithdrawn announces a member leaving or being expelled from the party
+func PartyWithdrawn(partyID, characterID uint, name string, expelled bool, data PartyData) protocol.Packet {
+	b := protocol.NewBuilder(SendPartyResult).
+		Byte(PartyResultWithdrawDone).
+		Int(int32(partyID)).
+		Int(int32(characterID)).
+		Bool(true). // bNotDisband
+		Bool(expelled).
+		String(name)
+	writePartyData(b, data)
+	return b.Build()
+}
+
+// PartyDisbanded closes the party window for every member
+func PartyDisbanded(partyID, leaderID uint) protocol.Packet {
+	return protocol.NewBuilder(SendPartyResult).
+		Byte(PartyResultWithdrawDone).
+		Int(int32(partyID)).
+		Int(int32(leaderID)).
+		Bool(false). // bNotDisband
+		Int(int32(partyID)).
+		Build()
+}
+
+// PartyBossChanged announces the new party leader
+func PartyBossChanged(leaderID uint, disconnected bool) protocol.Packet {
+	return protocol.NewBuilder(SendPartyResult).
+		Byte(PartyResultChangeBossDone).
+		Int(int32(leaderID)).
+		Bool(disconnected).
+		Build()
+}
+
+// PartyMessage shows a party result message with no arguments
+func PartyMessage(result byte) protocol.Packet {
+	return protocol.NewBuilder(SendPartyResult).
+		Byte(result).
+		Build()
+}
+
+// PartyMessageName shows a party result message about another character
+func PartyMessageName(result byte, name string) protocol.Packet {
+	return protocol.NewBuilder(SendPartyResult).
+		Byte(result).
+		String(name).
+		Build()
+}
+
+// UserHP updates a party member's HP bar
+func UserHP(characterID uint, hp, maxHP int32) protocol.Packet {
+	return protocol.NewBuilder(SendUserHP).
+		Int(int32(characterID)).
+		Int(hp).
+		Int(maxHP).
+		Build()
+}
+
+// writePartyData writes PARTYDATA, padding the member arrays to six slots
+func writePartyData(b *protocol.Builder, data PartyData) {
+	members := make([]PartyMember, MaxPartyMembers)
+	copy(members, data.Members)
+
+	for _, m := range members {
+		b.Int(int32(m.CharacterID))
+	}
+	for _, m := range members {
+		b.FixedString(m.Name, 13)
+	}
+	for _, m := range members {
+		b.Int(int32(m.Job))
+	}
+	for _, m := range members {
+		b.Int(int32(m.Level))
+	}
+	for i, m := range members {
+		if i < len(data.Members) {
+			b.Int(m.ChannelID)
+		} else {
+			b.Int(PartyChannelOffline)
+		}
+	}
+	b.Int(int32(data.LeaderID))
+
+	for i, m := range members {
+		if i < len(data.Members) && m.ChannelID != PartyChannelOffline {
+			b.Int(m.MapID)
+		} else {
+			b.Int(partyNoField)
+		}
+	}
+
+	// Town portals (mystic doors)
+	for range members {
+		b.Int(partyNoField) // dwTownID
+		b.Int(partyNoField) // dwFieldID
+		b.Int(0)            // nSkillID
+		b.Int(0)            // ptFieldPortal.x
+		b.Int(0)            // ptFieldPortal.y
+	}
+
+	for range members {
+		b.Int(0) // aPQReward
+	}
+	for range members {
+		b.Int(0) // aPQRewardType
+	}
+	b.Int(0) // dwPQRewardMobTemplateID
+	b.Int(0) // bPQReward
+}
@@ -125,6 +125,16 @@ func MessageQuestRecordCompleted(questID int32) protocol.Packet {
 		Build()
 }
 
+// MessageQuestRecordRemoved clears a quest record on the client (e.g. after forfeiting)
+func MessageQuestRecordRemoved(questID int32) protocol.Packet {
+	return protocol.NewBuilder(SendMessage).
+		Byte(MessageTypeQuestRecord).
+		Short(uint16(questID)).
+		Byte(byte(quest.QuestStateNone)).
+		Byte(0). // bDelete
+		Build()
+}
+
 // MessageQuestRecordEx sends extended quest record update
 func MessageQuestRecordEx(questID int32, value string) protocol.Packet {
 	return protocol.NewBuilder(SendMessage).
@@ -164,6 +174,15 @@ func MessageIncMoney(mesos int32) protocol.Packet {
 		Build()
 }
 
+// MessageIncSP sends SP gain message
+func MessageIncSP(job int16, sp byte) protocol.Packet {
+	return protocol.NewBuilder(SendMessage).
+		Byte(MessageTypeIncSP).
+		Short(uint16(job)).
+		Byte(sp).
+		Build()
+}
+
 // MessageIncPOP sends fame gain message
 func MessageIncPOP(fame int32) protocol.Packet {
 	return protocol.NewBuilder(SendMessage).
@@ -0,0 +1,66 @@
+package packets
+
+import (
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+// ChangeSkillRecordResult sends updated skill levels to the client.
+// exclRequest releases the client's skill window lock after a skill-up request.
+func ChangeSkillRecordResult(exclRequest bool, skills []*models.Skill) protocol.Packet {
+	p := protocol.NewWithOpcode(SendChangeSkillRecordResult)
+
+	p.WriteBool(exclRequest)
+	p.WriteShort(uint16(len(skills)))
+	for _, s := range skills {
+		p.WriteInt(s.SkillID)
+		p.WriteInt(int32(s.Level))
+		p.WriteInt(int32(s.MasterLevel))
+		WriteFT(&p, time.Time{}) // Expiration
+	}
+	p.WriteBool(false) // bSN
+
+	return p
+}
+
+// MacroSysDataInit sends the character's skill macros
+func MacroSysDataInit(macros []*models.SkillMacro) protocol.Packet {
+	p := protocol.NewWithOpcode(SendMacroSysDataInit)
+
+	p.WriteByte(byte(len(macros)))
+	for _, m := range macros {
+		p.WriteString(m.Name)
+		p.WriteBool(m.Shout)
+		p.WriteInt(m.Skill1)
+		p.WriteInt(m.Skill2)
+		p.WriteInt(m.Skill3)
+	}
+
+	return p
+}
+
+// WriteSkillRecords writes the SKILLRECORD block of the character data
+func WriteSkillRecords(p *protocol.Packet, skills []*models.Skill) {
+	p.WriteShort(uint16(len(skills)))
+	for _, s := range skills {
+		p.WriteInt(s.SkillID)
+		p.WriteInt(int32(s.Level))
+		WriteFT(p, time.Time{}) // Expiration
+		if game.IsSkillNeedMasterLevel(s.SkillID) {
+			p.WriteInt(int32(s.MasterLevel))
+		}
+	}
+}
+
+// WriteSkillCooltimes writes the SKILLCOOLTIME block of the character data
+func WriteSkillCooltimes(p *protocol.Packet, cooldowns []*models.SkillCooldown) {
+	now := time.Now()
+	p.WriteShort(uint16(len(cooldowns)))
+	for _, cd := range cooldowns {
+		p.WriteInt(cd.SkillID)
+		p.WriteShort(uint16(max(cd.ExpiresAt.Sub(now)/time.Second, 0))) // Remaining seconds
+	}
+}
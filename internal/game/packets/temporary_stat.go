@@ -0,0 +1,251 @@
+package packets
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+// CharacterTemporaryStat is a buff or debuff on a character, numbered by its
+// bit in the client's 128-bit secondary stat mask
+type CharacterTemporaryStat uint8
+
+const (
+	CTSPAD CharacterTemporaryStat = iota
+	CTSPDD
+	CTSMAD
+	CTSMDD
+	CTSACC
+	CTSEVA
+	CTSCraft
+	CTSSpeed
+	CTSJump
+	CTSMagicGuard
+	CTSDarkSight
+	CTSBooster
+	CTSPowerGuard
+	CTSMaxHP
+	CTSMaxMP
+	CTSInvincible
+	CTSSoulArrow
+	CTSStun
+	CTSPoison
+	CTSSeal
+	CTSDarkness
+	CTSComboCounter
+	CTSWeaponCharge
+	CTSDragonBlood
+	CTSHolySymbol
+	CTSMesoUp
+	CTSShadowPartner
+	CTSPickPocket
+	CTSMesoGuard
+	CTSThaw
+	CTSWeakness
+	CTSCurse
+	CTSSlow
+	CTSMorph
+	CTSRegen
+	CTSBasicStatUp
+	CTSStance
+	CTSSharpEyes
+	CTSManaReflection
+	CTSAttract
+	CTSSpiritJavelin
+	CTSInfinity
+	CTSHolyShield
+	CTSHamString
+	CTSBlind
+	CTSConcentration
+	CTSBanMap
+	CTSMaxLevelBuff
+	CTSMesoUpByItem
+	CTSGhost
+	CTSBarrier
+)
+
+// TemporaryStatValue is the value of one active temporary stat
+type TemporaryStatValue struct {
+	Option   int32 // nOption: the stat's strength
+	Reason   int32 // rOption: the skill ID, or negated item ID, that applied it
+	ExpireAt time.Time
+}
+
+// TemporaryStats maps each active temporary stat to its value
+type TemporaryStats map[CharacterTemporaryStat]TemporaryStatValue
+
+// IsMovementAffecting returns true if the stat changes how the client moves,
+// which makes the client expect an extra byte after the stat block
+func (s CharacterTemporaryStat) IsMovementAffecting() bool {
+	switch s {
+	case CTSSpeed, CTSJump, CTSStun, CTSWeakness, CTSSlow, CTSMorph, CTSGhost, CTSBasicStatUp, CTSAttract:
+		return true
+	}
+	return false
+}
+
+// remoteStats are the stats other players can see, in the fixed order
+// SecondaryStat::DecodeForRemote reads them in, which isn't their bit order
+var remoteStats = []CharacterTemporaryStat{
+	CTSSpeed, CTSComboCounter, CTSWeaponCharge, CTSStun, CTSDarkness, CTSSeal, CTSWeakness,
+	CTSCurse, CTSPoison, CTSShadowPartner, CTSDarkSight, CTSSoulArrow, CTSMorph, CTSGhost,
+	CTSAttract, CTSSpiritJavelin, CTSBanMap, CTSBarrier,
+}
+
+// IsRemote returns true for stats other players can see
+func (s CharacterTemporaryStat) IsRemote() bool {
+	for _, r := range remoteStats {
+		if r == s {
+			return true
+		}
+	}
+	return false
+}
+
+// tempStatMask is the 128-bit flag mask that prefixes temporary stat blocks
+type tempStatMask [4]uint32
+
+func (m *tempStatMask) set(bit uint8) {
+	m[bit/32] |= 1 << (31 - bit%32)
+}
+
+func (m *tempStatMask) write(p *protocol.Packet) {
+	for _, v := range m {
+		p.WriteInt(int32(v))
+	}
+}
+
+// sortedStats returns the stats in bit order, which is the order the client decodes them in
+func sortedStats(stats TemporaryStats) []CharacterTemporaryStat {
+	keys := make([]CharacterTemporaryStat, 0, len(stats))
+	for s := range stats {
+		keys = append(keys, s)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// anyMovementAffecting returns true if any of the stats affects movement
+func anyMovementAffecting(stats []CharacterTemporaryStat) bool {
+	for _, s := range stats {
+		if s.IsMovementAffecting() {
+			return true
+		}
+	}
+	return false
+}
+
+// writeTemporaryStatsLocal writes SecondaryStat::DecodeForLocal
+func writeTemporaryStatsLocal(p *protocol.Packet, stats TemporaryStats) {
+	keys := sortedStats(stats)
+
+	var mask tempStatMask
+	for _, s := range keys {
+		mask.set(uint8(s))
+	}
+	mask.write(p)
+
+	now := time.Now()
+	for _, s := range keys {
+		v := stats[s]
+		p.WriteShort(uint16(v.Option))
+		p.WriteInt(v.Reason)
+		p.WriteInt(int32(max(v.ExpireAt.Sub(now)/time.Millisecond, 0))) // Remaining duration
+	}
+
+	p.WriteByte(0) // nDefenseAtt
+	p.WriteByte(0) // nDefenseState
+}
+
+// WriteTemporaryStatsRemote writes SecondaryStat::DecodeForRemote in its decode order,
+// skipping stats that other players can't see
+func WriteTemporaryStatsRemote(p *protocol.Packet, stats TemporaryStats) {
+	var keys []CharacterTemporaryStat
+	for _, s := range remoteStats {
+		if _, ok := stats[s]; ok {
+			keys = append(keys, s)
+		}
+	}
+
+	var mask tempStatMask
+	for _, s := range keys {
+		mask.set(uint8(s))
+	}
+	mask.write(p)
+
+	for _, s := range keys {
+		v := stats[s]
+		switch s {
+		case CTSSpeed, CTSComboCounter:
+			p.WriteByte(byte(v.Option))
+		case CTSWeaponCharge, CTSStun, CTSDarkness, CTSSeal, CTSWeakness, CTSCurse, CTSShadowPartner, CTSAttract:
+			p.WriteInt(v.Reason)
+		case CTSPoison:
+			p.WriteShort(uint16(v.Option))
+			p.WriteInt(v.Reason)
+		case CTSMorph, CTSGhost:
+			p.WriteShort(uint16(v.Option))
+		case CTSSpiritJavelin, CTSBanMap, CTSBarrier:
+			p.WriteInt(v.Option)
+		}
+		// Dark Sight and Soul Arrow are flag-only
+	}
+
+	p.WriteByte(0) // nDefenseAtt
+	p.WriteByte(0) // nDefenseState
+}
+
+// TemporaryStatSet sends newly applied buffs/debuffs to their owner
+func TemporaryStatSet(stats TemporaryStats) protocol.Packet {
+	p := protocol.NewWithOpcode(SendTemporaryStatSet)
+
+	writeTemporaryStatsLocal(&p, stats)
+	p.WriteShort(0) // tDelay
+	if anyMovementAffecting(sortedStats(stats)) {
+		p.WriteByte(0)
+	}
+
+	return p
+}
+
+// TemporaryStatReset tells the owner that buffs/debuffs have ended
+func TemporaryStatReset(stats []CharacterTemporaryStat) protocol.Packet {
+	p := protocol.NewWithOpcode(SendTemporaryStatReset)
+
+	var mask tempStatMask
+	for _, s := range stats {
+		mask.set(uint8(s))
+	}
+	mask.write(&p)
+	if anyMovementAffecting(stats) {
+		p.WriteByte(0)
+	}
+
+	return p
+}
+
+// UserTemporaryStatSet shows a character's visible buffs/debuffs to other players
+func UserTemporaryStatSet(characterID uint, stats TemporaryStats) protocol.Packet {
+	p := protocol.NewWithOpcode(SendUserTemporaryStatSet)
+
+	p.WriteInt(int32(characterID))
+	WriteTemporaryStatsRemote(&p, stats)
+	p.WriteShort(0) // tDelay
+
+	return p
+}
+
+// UserTemporaryStatReset removes a character's buffs/debuffs for other players
+func UserTemporaryStatReset(characterID uint, stats []CharacterTemporaryStat) protocol.Packet {
+	p := protocol.NewWithOpcode(SendUserTemporaryStatReset)
+
+	p.WriteInt(int32(characterID))
+	var mask tempStatMask
+	for _, s := range stats {
+		mask.set(uint8(s))
+	}
+	mask.write(&p)
+
+	return p
+}
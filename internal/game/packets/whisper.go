@@ -0,0 +1,68 @@
+package packets
+
+import "github.com/Jinw00Arise/Jinwoo/internal/protocol"
+
+// Whisper flags. Requests from the client combine a kind with WhisperFlagRequest;
+// replies combine it with WhisperFlagResult or WhisperFlagReceive.
+const (
+	WhisperFlagLocation       byte = 0x01 // /find
+	WhisperFlagWhisper        byte = 0x02
+	WhisperFlagRequest        byte = 0x04
+	WhisperFlagResult         byte = 0x08
+	WhisperFlagReceive        byte = 0x10
+	WhisperFlagBlocked        byte = 0x20
+	WhisperFlagLocationFriend byte = 0x40 // Find from the buddy window
+	WhisperFlagManager        byte = 0x80
+)
+
+// Location types for a /find result
+const (
+	WhisperLocationNone         byte = 0
+	WhisperLocationField        byte = 1 // Same channel, value is the map ID
+	WhisperLocationCashShop     byte = 2
+	WhisperLocationOtherChannel byte = 3 // Value is the channel ID
+)
+
+// WhisperResult tells the sender whether their whisper was delivered. A failed
+// result also answers a /find for a character that can't be found.
+func WhisperResult(targetName string, success bool) protocol.Packet {
+	return protocol.NewBuilder(SendWhisper).
+		Byte(WhisperFlagWhisper | WhisperFlagResult).
+		String(targetName).
+		Bool(success).
+		Build()
+}
+
+// WhisperReceive delivers a whisper to its target
+func WhisperReceive(senderName string, channelID byte, fromAdmin bool, text string) protocol.Packet {
+	return protocol.NewBuilder(SendWhisper).
+		Byte(WhisperFlagWhisper | WhisperFlagReceive).
+		String(senderName).
+		Byte(channelID).
+		Bool(fromAdmin).
+		String(text).
+		Build()
+}
+
+// WhisperBlocked tells the sender the target can't be reached
+func WhisperBlocked(targetName string) protocol.Packet {
+	return protocol.NewBuilder(SendWhisper).
+		Byte(WhisperFlagWhisper | WhisperFlagBlocked).
+		String(targetName).
+		Byte(0).
+		Build()
+}
+
+// WhisperLocationResult answers a /find. flag is WhisperFlagLocation or
+// WhisperFlagLocationFriend, matching the request.
+func WhisperLocationResult(flag byte, targetName string, location byte, value int32, x, y int32) protocol.Packet {
+	b := protocol.NewBuilder(SendWhisper).
+		Byte(flag | WhisperFlagResult).
+		String(targetName).
+		Byte(location).
+		Int(value)
+	if location == WhisperLocationField {
+		b.Int(x).Int(y)
+	}
+	return b.Build()
+}
@@ -271,6 +271,8 @@ func (m *CharacterQuestManager) StartQuest(questID int32) error {
 		}
 	}
 
+	// Repeatable quests move back to in-progress
+	delete(m.complete, questID)
 	m.started[questID] = qr
 	return nil
 }
@@ -358,6 +360,43 @@ func (m *CharacterQuestManager) GetMobProgress(questID int32, mobID int32) (curr
 	return current, required
 }
 
+// GetProgressString returns a quest's progress in the stored "mobID=count;..." format
+func (m *CharacterQuestManager) GetProgressString(questID int32) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if qr, ok := m.started[questID]; ok {
+		return formatProgress(qr.Progress)
+	}
+	if qr, ok := m.complete[questID]; ok {
+		return formatProgress(qr.Progress)
+	}
+	return ""
+}
+
+// GetClientProgress returns a quest's mob kill counts as the client expects them:
+// three digits per required mob, in quest data order
+func (m *CharacterQuestManager) GetClientProgress(questID int32) string {
+	qd := m.provider.GetQuest(questID)
+	if qd == nil || qd.CheckEnd == nil {
+		return ""
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	qr, ok := m.started[questID]
+	if !ok {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, mob := range qd.CheckEnd.Mobs {
+		fmt.Fprintf(&sb, "%03d", min(qr.Progress[mob.MobID], 999))
+	}
+	return sb.String()
+}
+
 // GetQuestRewards returns the rewards for completing a quest
 func (m *CharacterQuestManager) GetQuestRewards(questID int32) *quest.QuestAct {
 	qd := m.provider.GetQuest(questID)
@@ -4,6 +4,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
 	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
 	lua "github.com/yuin/gopher-lua"
 )
@@ -161,6 +162,47 @@ func registerCommonBindings(L *lua.LState, char CharacterAccessor) {
 		return 1
 	}))
 
+	// Guild
+	L.SetField(playerTable, "getGuildId", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LNumber(char.GuildID()))
+		return 1
+	}))
+
+	L.SetField(playerTable, "getGuildGrade", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LNumber(char.GuildGrade()))
+		return 1
+	}))
+
+	L.SetField(playerTable, "isGuildMaster", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LBool(char.GuildID() != 0 && char.GuildGrade() == models.GuildGradeMaster))
+		return 1
+	}))
+
+	L.SetField(playerTable, "createGuild", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LBool(char.OpenGuildCreation()))
+		return 1
+	}))
+
+	L.SetField(playerTable, "changeGuildMark", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LBool(char.OpenGuildMarkChange()))
+		return 1
+	}))
+
+	L.SetField(playerTable, "getGuildCapacityCost", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LNumber(char.GuildCapacityCost()))
+		return 1
+	}))
+
+	L.SetField(playerTable, "increaseGuildCapacity", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LBool(char.IncreaseGuildCapacity()))
+		return 1
+	}))
+
+	L.SetField(playerTable, "disbandGuild", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LBool(char.DisbandGuild()))
+		return 1
+	}))
+
 	L.SetGlobal("player", playerTable)
 
 	// Utility functions
@@ -281,8 +323,7 @@ func registerNPCBindings(L *lua.LState, ctx *NPCContext) {
 		return 1
 	}))
 
-	// Conversation functions - these are synchronous for now
-	// In a real implementation, these would need to be async/yield-based
+	// Conversation functions - these block the script goroutine until the client answers
 
 	L.SetField(npcTable, "say", L.NewFunction(func(L *lua.LState) int {
 		text := L.CheckString(1)
@@ -405,94 +446,107 @@ func sendNPCSay(ctx *NPCContext, text string, next, prev bool) {
 	packet := packets.ScriptMessageSay(ctx.NPCID, text, prev, next, 0)
 	if err := ctx.Character.Write(packet); err != nil {
 		log.Printf("[NPC %d] Failed to send say packet: %v", ctx.NPCID, err)
-		return
+		panic("chat ended by player")
 	}
 
-	select {
-	case <-ctx.ResponseChan:
-	case <-time.After(dialogTimeout):
-		log.Printf("[NPC %d] Dialog timeout", ctx.NPCID)
-	}
+	waitForNPCResponse(ctx)
 }
 
 func sendNPCAskYesNo(ctx *NPCContext, text string) bool {
 	packet := packets.ScriptMessageAskYesNo(ctx.NPCID, text)
 	if err := ctx.Character.Write(packet); err != nil {
 		log.Printf("[NPC %d] Failed to send askYesNo packet: %v", ctx.NPCID, err)
-		return false
+		panic("chat ended by player")
 	}
 
-	select {
-	case response := <-ctx.ResponseChan:
-		if response.Ended {
-			return false
-		}
-		return response.Type == NPCResponseYes
-	case <-time.After(dialogTimeout):
-		log.Printf("[NPC %d] Dialog timeout", ctx.NPCID)
-		return false
-	}
+	return waitForNPCResponse(ctx).Type == NPCResponseYes
 }
 
 func sendNPCAskMenu(ctx *NPCContext, text string) int {
 	packet := packets.ScriptMessageAskMenu(ctx.NPCID, text)
 	if err := ctx.Character.Write(packet); err != nil {
 		log.Printf("[NPC %d] Failed to send askMenu packet: %v", ctx.NPCID, err)
-		return -1
+		panic("chat ended by player")
 	}
 
-	select {
-	case response := <-ctx.ResponseChan:
-		if response.Ended {
-			return -1
-		}
-		return response.Selection
-	case <-time.After(dialogTimeout):
-		log.Printf("[NPC %d] Dialog timeout", ctx.NPCID)
-		return -1
-	}
+	response := waitForNPCResponse(ctx)
+	ctx.Selection = response.Selection
+	return response.Selection
 }
 
 func sendNPCAskText(ctx *NPCContext, text, defaultText string, minLen, maxLen int16) string {
 	packet := packets.ScriptMessageAskText(ctx.NPCID, text, defaultText, minLen, maxLen)
 	if err := ctx.Character.Write(packet); err != nil {
 		log.Printf("[NPC %d] Failed to send askText packet: %v", ctx.NPCID, err)
-		return ""
+		panic("chat ended by player")
 	}
 
-	select {
-	case response := <-ctx.ResponseChan:
-		if response.Ended {
-			return ""
-		}
-		return response.Text
-	case <-time.After(dialogTimeout):
-		log.Printf("[NPC %d] Dialog timeout", ctx.NPCID)
-		return ""
-	}
+	response := waitForNPCResponse(ctx)
+	ctx.InputText = response.Text
+	return response.Text
 }
 
 func sendNPCAskNumber(ctx *NPCContext, text string, defaultVal, minVal, maxVal int32) int32 {
 	packet := packets.ScriptMessageAskNumber(ctx.NPCID, text, defaultVal, minVal, maxVal)
 	if err := ctx.Character.Write(packet); err != nil {
 		log.Printf("[NPC %d] Failed to send askNumber packet: %v", ctx.NPCID, err)
-		return defaultVal
+		panic("chat ended by player")
+	}
+
+	response := waitForNPCResponse(ctx)
+	ctx.InputNumber = response.Number
+	return response.Number
+}
+
+// Say shows an OK dialog and waits for the player to close it
+func (ctx *NPCContext) Say(text string) {
+	sendNPCSay(ctx, text, false, false)
+}
+
+// SayNext shows a dialog with a Next button and waits for the player to continue
+func (ctx *NPCContext) SayNext(text string) {
+	sendNPCSay(ctx, text, true, false)
+}
+
+// AskYesNo shows a Yes/No dialog and returns true if the player chose Yes
+func (ctx *NPCContext) AskYesNo(text string) bool {
+	return sendNPCAskYesNo(ctx, text)
+}
+
+// AskAccept shows an Accept/Decline dialog and returns true if the player accepted
+func (ctx *NPCContext) AskAccept(text string) bool {
+	packet := packets.ScriptMessageAskAccept(ctx.NPCID, text)
+	if err := ctx.Character.Write(packet); err != nil {
+		log.Printf("[NPC %d] Failed to send askAccept packet: %v", ctx.NPCID, err)
+		panic("chat ended by player")
 	}
 
+	return waitForNPCResponse(ctx).Type == NPCResponseYes
+}
+
+// AskMenu shows a #L selection menu and returns the chosen index
+func (ctx *NPCContext) AskMenu(text string) int {
+	return sendNPCAskMenu(ctx, text)
+}
+
+// waitForNPCResponse blocks until the client answers. Closing the dialog or timing
+// out unwinds the script with the "chat ended by player" panic.
+func waitForNPCResponse(ctx *NPCContext) NPCResponse {
 	select {
-	case response := <-ctx.ResponseChan:
-		if response.Ended {
-			return defaultVal
+	case response, ok := <-ctx.ResponseChan:
+		if !ok || response.Ended {
+			panic("chat ended by player")
 		}
-		return response.Number
+		return response
 	case <-time.After(dialogTimeout):
 		log.Printf("[NPC %d] Dialog timeout", ctx.NPCID)
-		return defaultVal
+		panic("chat ended by player")
 	}
 }
 
 func disposeNPCConversation(ctx *NPCContext) {
-	// The conversation will be cleaned up when the script ends
+	// Unwind the script; ExecuteNPCScript ends the conversation and runs OnComplete
+	panic("chat ended by player")
 }
 
 // Portal conversation packet helpers
@@ -42,6 +42,15 @@ type CharacterAccessor interface {
 
 	// Warping - takes map ID and portal name
 	TransferField(targetMapID int32, portalName string)
+
+	// Guild
+	GuildID() uint
+	GuildGrade() byte
+	OpenGuildCreation() bool
+	OpenGuildMarkChange() bool
+	GuildCapacityCost() int32
+	IncreaseGuildCapacity() bool
+	DisbandGuild() bool
 }
 
 // PortalContext holds context for portal script execution
@@ -93,6 +102,9 @@ type NPCContext struct {
 
 	// Response channel for async conversation
 	ResponseChan chan NPCResponse
+
+	// OnComplete is called when the script finishes (for cleanup like EnableActions)
+	OnComplete func()
 }
 
 // NPCResponse represents a response from NPC conversation
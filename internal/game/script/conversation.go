@@ -68,6 +68,16 @@ func (cm *ConversationManager) EndConversation(characterID uint) {
 	cm.endExistingConversationsLocked(characterID)
 }
 
+// EndNPCConversation removes ctx if it is still the character's active NPC conversation
+func (cm *ConversationManager) EndNPCConversation(characterID uint, ctx *NPCContext) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if current, ok := cm.npcConversations[characterID]; ok && current == ctx {
+		delete(cm.npcConversations, characterID)
+	}
+}
+
 // GetConversation returns the active NPC conversation for a character
 func (cm *ConversationManager) GetConversation(characterID uint) (*NPCContext, bool) {
 	cm.mu.RLock()
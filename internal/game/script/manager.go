@@ -214,7 +214,7 @@ func (m *Manager) ExecutePortalScript(ctx *PortalContext) error {
 		L.Push(lfunc)
 
 		// Execute
-		if err := L.PCall(0, 0, nil); err != nil {
+		if err := L.PCall(0, 0, nil); err != nil && !isChatEnded(err) {
 			log.Printf("[Script] Portal script error for %s: %v", ctx.PortalName, err)
 		}
 	}()
@@ -222,7 +222,8 @@ func (m *Manager) ExecutePortalScript(ctx *PortalContext) error {
 	return nil
 }
 
-// ExecuteNPCScript executes an NPC script
+// ExecuteNPCScript executes an NPC script in a goroutine (async for dialog support)
+// Returns immediately - script runs asynchronously
 func (m *Manager) ExecuteNPCScript(ctx *NPCContext) error {
 	scriptName := ctx.ScriptName
 	if scriptName == "" {
@@ -236,24 +237,56 @@ func (m *Manager) ExecuteNPCScript(ctx *NPCContext) error {
 		return err
 	}
 
-	L := m.getState()
-	defer m.putState(L)
+	m.StartNPCConversation(ctx, func() {
+		L := m.getState()
+		defer m.putState(L)
 
-	// Register NPC bindings
-	registerNPCBindings(L, ctx)
+		// Register NPC bindings
+		registerNPCBindings(L, ctx)
 
-	// Load the compiled function
-	lfunc := L.NewFunctionFromProto(proto)
-	L.Push(lfunc)
+		// Load the compiled function
+		lfunc := L.NewFunctionFromProto(proto)
+		L.Push(lfunc)
 
-	// Execute
-	if err := L.PCall(0, 0, nil); err != nil {
-		return fmt.Errorf("npc script error: %w", err)
-	}
+		// Execute
+		if err := L.PCall(0, 0, nil); err != nil && !isChatEnded(err) {
+			log.Printf("[Script] NPC script error for %s: %v", scriptName, err)
+		}
+	})
 
 	return nil
 }
 
+// StartNPCConversation registers ctx as the character's NPC conversation and runs fn
+// in a goroutine so it can block on dialog answers. The conversation is ended and
+// OnComplete called once fn returns or the player closes the dialog.
+func (m *Manager) StartNPCConversation(ctx *NPCContext, fn func()) {
+	m.conversations.StartConversation(ctx.Character.ID(), ctx)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				// "chat ended by player" is expected when user closes dialog
+				if r != "chat ended by player" {
+					log.Printf("[Script] NPC conversation panic for %d: %v", ctx.NPCID, r)
+				}
+			}
+			m.conversations.EndNPCConversation(ctx.Character.ID(), ctx)
+			if ctx.OnComplete != nil {
+				ctx.OnComplete()
+			}
+		}()
+
+		fn()
+	}()
+}
+
+// isChatEnded reports whether a script error is the "chat ended by player" unwind,
+// which PCall converts from a Go panic into an error
+func isChatEnded(err error) bool {
+	return strings.Contains(err.Error(), "chat ended by player")
+}
+
 // ExecuteQuestScript executes a quest script
 func (m *Manager) ExecuteQuestScript(ctx *QuestContext, isStart bool) error {
 	var scriptName string
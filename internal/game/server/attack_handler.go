@@ -0,0 +1,208 @@
+package server
+
+import (
+	"log"
+	"math/rand/v2"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers"
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+const (
+	// maxDamageLine is the v95 client's damage cap for a single line
+	maxDamageLine = 999999
+
+	// criticalAllowance is the room left over a line's computed max damage for
+	// criticals and damage buffs
+	criticalAllowance = 2.0
+
+	// shadowPartnerLines is how many times more lines an attack hits with Shadow Partner
+	shadowPartnerLines = 2
+)
+
+func (h *ChannelHandler) handleUserAttack(reader *protocol.Reader, attackType field.AttackType) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	currentField := character.Field()
+	if currentField == nil {
+		return
+	}
+
+	attack := field.DecodeAttack(reader, attackType)
+	if attack.DamagePerMob() > 15 || attack.MobCount() > 15 {
+		log.Printf("[Attack] %s sent malformed attack mask 0x%02x", character.Name(), attack.Mask)
+		return
+	}
+	if attack.SkillID != 0 && character.SkillLevel(attack.SkillID) == 0 {
+		log.Printf("[Attack] %s attacked with unlearned skill %d", character.Name(), attack.SkillID)
+		return
+	}
+
+	skillLevel := h.attackSkill(character, attack)
+	if !withinSkillCounts(character, attack, skillLevel) {
+		log.Printf("[Attack] %s hit %d mobs %d times with skill %d, more than it allows",
+			character.Name(), attack.MobCount(), attack.DamagePerMob(), attack.SkillID)
+		return
+	}
+	if skillLevel != nil {
+		changed, err := character.UseSkill(attack.SkillID, skillLevel)
+		if err != nil {
+			log.Printf("[Attack] %s: skill use %d failed: %v", character.Name(), attack.SkillID, err)
+			return
+		}
+		if len(changed) > 0 {
+			h.client.Write(packets.StatChanged(false, changed))
+		}
+	}
+
+	var bulletItemID int32
+	if attackType == field.AttackTypeShoot && attack.BulletSlot > 0 {
+		if bullet := character.ItemAt(models.InvConsume, attack.BulletSlot); bullet != nil {
+			bulletItemID = bullet.ItemID
+		}
+	}
+
+	lineCap := damageCap(character, attack, skillLevel)
+	for i := range attack.Mobs {
+		info := &attack.Mobs[i]
+		for j := range info.Lines {
+			if info.Lines[j].Damage > lineCap {
+				log.Printf("[Attack] %s hit mob object %d for %d, capped to %d (skill %d)",
+					character.Name(), info.ObjectID, info.Lines[j].Damage, lineCap, attack.SkillID)
+				info.Lines[j].Damage = lineCap
+			}
+		}
+	}
+
+	// Others see the attack before any mob it kills disappears
+	currentField.BroadcastExcept(UserAttack(character, attack, attackSkillLevel(character, attack), bulletItemID), character)
+
+	mobStats, statusLevel := h.attackMobStatus(character, attack)
+
+	for i := range attack.Mobs {
+		info := &attack.Mobs[i]
+		mob := currentField.GetMob(info.ObjectID)
+		if mob == nil || mob.IsDead() {
+			continue
+		}
+
+		total := info.TotalDamage()
+		if total > int64(mob.MaxHP()) {
+			total = int64(mob.MaxHP())
+		}
+
+		if _, killed := mob.Damage(character.ID(), int32(total)); killed {
+			h.client.Channel().killMob(h.client, currentField, mob)
+			continue
+		}
+
+		currentField.Broadcast(packets.MobHP(mob.ObjectID(), mobHPPercent(mob)))
+
+		// Bosses shrug off stuns and freezes
+		if mobStats != nil && !mob.IsBoss() && (statusLevel.Prop <= 0 || rand.Int32N(100) < statusLevel.Prop) {
+			currentField.SetMobTemporaryStats(mob, attack.SkillID, field.SkillBuffDuration(statusLevel), mobStats)
+		}
+	}
+}
+
+// attackSkill returns the level data of the attack's skill, or nil for basic attacks
+// and skills without data
+func (h *ChannelHandler) attackSkill(character *field.Character, attack *field.AttackInfo) *providers.SkillLevel {
+	if attack.SkillID == 0 {
+		return nil
+	}
+	skill := h.client.server.SkillProvider().GetSkill(attack.SkillID)
+	if skill == nil {
+		return nil
+	}
+	return skill.Level(character.SkillLevel(attack.SkillID))
+}
+
+// withinSkillCounts checks the attack hits no more mobs, and no more lines per mob,
+// than its skill level allows. Shadow Partner doubles the lines.
+func withinSkillCounts(character *field.Character, attack *field.AttackInfo, lv *providers.SkillLevel) bool {
+	if lv == nil {
+		return true
+	}
+
+	lines := lv.AttackCount
+	if _, ok := character.TemporaryStat(packets.CTSShadowPartner); ok {
+		lines *= shadowPartnerLines
+	}
+	if lines > 0 && int32(attack.DamagePerMob()) > lines {
+		return false
+	}
+	if lv.MobCount > 0 && int32(attack.MobCount()) > lv.MobCount {
+		return false
+	}
+	return true
+}
+
+// attackMobStatus returns the statuses the attack's skill inflicts on the mobs it
+// hits and the skill level they're rolled and timed from, or nil if there are none
+func (h *ChannelHandler) attackMobStatus(character *field.Character, attack *field.AttackInfo) (map[packets.MobTemporaryStat]int32, *providers.SkillLevel) {
+	stats := field.SkillMobStats(attack.SkillID)
+	if len(stats) == 0 {
+		return nil, nil
+	}
+	skill := h.client.server.SkillProvider().GetSkill(attack.SkillID)
+	if skill == nil {
+		return nil, nil
+	}
+	lv := skill.Level(character.SkillLevel(attack.SkillID))
+	if lv == nil || lv.Time <= 0 {
+		return nil, nil
+	}
+	return stats, lv
+}
+
+// attackSkillLevel returns the level the attack's skill is shown at to other players
+func attackSkillLevel(character *field.Character, attack *field.AttackInfo) byte {
+	if attack.SkillID == 0 {
+		return 0
+	}
+	return byte(character.SkillLevel(attack.SkillID))
+}
+
+// damageCap returns the highest damage a single line of this attack may deal,
+// estimated from the character's calculated damage range and the skill level's
+// damage percentage, or its spell attack for magic
+func damageCap(character *field.Character, attack *field.AttackInfo, lv *providers.SkillLevel) int32 {
+	stats := character.CalcStats()
+
+	limit := float64(stats.DamageMax)
+	if attack.Type == field.AttackTypeMagic {
+		limit = float64(stats.MagicMax)
+	}
+	switch {
+	case lv != nil && attack.Type == field.AttackTypeMagic && lv.MAD > 0:
+		mad := float64(stats.MAD)
+		limit = ((mad*mad/1000+mad)/30 + float64(stats.INT)/200) * float64(lv.MAD)
+	case lv != nil && lv.Damage > 0:
+		limit = limit * float64(lv.Damage) / 100
+	}
+
+	limit *= criticalAllowance
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > maxDamageLine {
+		return maxDamageLine
+	}
+	return int32(limit)
+}
+
+// mobHPPercent returns the mob's remaining HP as a percentage for the HP bar
+func mobHPPercent(mob *field.Mob) byte {
+	maxHP := int64(mob.MaxHP())
+	if maxHP <= 0 {
+		return 0
+	}
+	return byte(int64(mob.HP()) * 100 / maxHP)
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"log"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+)
+
+// notifyBuddies pushes a character's online state to everyone who has them as an accepted buddy
+func (s *Server) notifyBuddies(charID uint, channelID int32, inShop bool) {
+	buddies := s.repos.Buddies
+	if buddies == nil {
+		return
+	}
+
+	friendIDs, err := buddies.GetFriendsOf(s.ctx, charID)
+	if err != nil {
+		log.Printf("[Buddy] Failed to load friends of character %d: %v", charID, err)
+		return
+	}
+
+	p := packets.FriendNotify(charID, channelID, inShop)
+	for _, friendID := range friendIDs {
+		if client, ok := s.GetOnlineClient(friendID); ok {
+			_ = client.Write(p)
+		}
+	}
+}
+
+// friendOf builds the buddy window entry for a stored buddy, with their current online state
+func (s *Server) friendOf(buddy *models.Buddy) packets.Friend {
+	friend := packets.Friend{
+		CharacterID: buddy.BuddyID,
+		Name:        buddy.BuddyName,
+		Flag:        packets.FriendFlagNormal,
+		ChannelID:   packets.FriendChannelOffline,
+		Group:       buddy.GroupName,
+	}
+
+	if models.BuddyStatus(buddy.Status) == models.BuddyStatusSent {
+		friend.Flag = packets.FriendFlagRequest
+		return friend
+	}
+	if info, ok := s.GetOnlineCharacter(buddy.BuddyID); ok {
+		friend.ChannelID = int32(info.ChannelID)
+		friend.InShop = info.InCashShop
+	}
+	return friend
+}
+
+// loadFriends returns a character's buddy window entries and the buddy requests
+// they haven't answered yet
+func (s *Server) loadFriends(ctx context.Context, charID uint) ([]packets.Friend, []*models.Buddy, error) {
+	buddies, err := s.repos.Buddies.GetBuddies(ctx, charID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var friends []packets.Friend
+	var requests []*models.Buddy
+	for _, buddy := range buddies {
+		if models.BuddyStatus(buddy.Status) == models.BuddyStatusReceived {
+			requests = append(requests, buddy)
+			continue
+		}
+		friends = append(friends, s.friendOf(buddy))
+	}
+	return friends, requests, nil
+}
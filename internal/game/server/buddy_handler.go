@@ -0,0 +1,257 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+	"gorm.io/gorm"
+)
+
+// maxBuddyGroupLength is the longest group name the buddy window accepts
+const maxBuddyGroupLength = 16
+
+func (h *ChannelHandler) handleFriendRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil || h.client.server.Repos().Buddies == nil {
+		return
+	}
+
+	switch request := reader.ReadByte(); request {
+	case packets.FriendRequestLoad:
+		h.sendFriendList(character, packets.FriendResultLoadDone)
+	case packets.FriendRequestSet:
+		name := reader.ReadString()
+		group := reader.ReadString()
+		h.setFriend(character, name, group)
+	case packets.FriendRequestAccept:
+		friendID := uint(reader.ReadInt())
+		h.acceptFriend(character, friendID)
+	case packets.FriendRequestDelete:
+		friendID := uint(reader.ReadInt())
+		h.deleteFriend(character, friendID)
+	default:
+		log.Printf("[Buddy] Unhandled friend request %d from %s", request, character.Name())
+	}
+}
+
+// sendFriendList sends the character's buddy list with the given result type
+func (h *ChannelHandler) sendFriendList(character *field.Character, result byte) {
+	server := h.client.server
+	friends, _, err := server.loadFriends(server.Context(), character.ID())
+	if err != nil {
+		log.Printf("[Buddy] Failed to load buddies for %s: %v", character.Name(), err)
+		h.client.Write(packets.FriendMessage(packets.FriendResultUnknown))
+		return
+	}
+	h.client.Write(packets.FriendList(result, friends))
+}
+
+// sendBuddiesOnLogin sends the buddy list and any requests received while offline
+func (h *ChannelHandler) sendBuddiesOnLogin(character *field.Character) {
+	server := h.client.server
+	if server.Repos().Buddies == nil {
+		return
+	}
+
+	ctx := server.Context()
+	friends, requests, err := server.loadFriends(ctx, character.ID())
+	if err != nil {
+		log.Printf("[Buddy] Failed to load buddies for %s: %v", character.Name(), err)
+		return
+	}
+	h.client.Write(packets.FriendList(packets.FriendResultLoadDone, friends))
+
+	for _, request := range requests {
+		from, err := server.Repos().Characters.FindByID(ctx, request.BuddyID)
+		if err != nil {
+			log.Printf("[Buddy] Failed to load buddy request sender %d: %v", request.BuddyID, err)
+			continue
+		}
+		h.client.Write(packets.FriendInvite(server.friendOf(&models.Buddy{
+			BuddyID:   from.ID,
+			BuddyName: from.Name,
+			GroupName: models.DefaultBuddyGroup,
+		}), int32(from.Level), int32(from.Job)))
+	}
+}
+
+// setFriend sends a buddy request by name, or moves an existing buddy to another group
+func (h *ChannelHandler) setFriend(character *field.Character, name, group string) {
+	server := h.client.server
+	ctx := server.Context()
+	repo := server.Repos().Buddies
+
+	if group == "" {
+		group = models.DefaultBuddyGroup
+	}
+	if len(group) > maxBuddyGroupLength {
+		h.client.Write(packets.FriendMessage(packets.FriendResultUnknown))
+		return
+	}
+	if strings.EqualFold(name, character.Name()) {
+		h.client.Write(packets.FriendMessage(packets.FriendResultUnknownUser))
+		return
+	}
+
+	target, err := server.Repos().Characters.FindByName(ctx, h.client.Channel().World().ID(), name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		h.client.Write(packets.FriendMessage(packets.FriendResultUnknownUser))
+		return
+	}
+	if err != nil {
+		log.Printf("[Buddy] Failed to look up %s: %v", name, err)
+		h.client.Write(packets.FriendMessage(packets.FriendResultUnknown))
+		return
+	}
+
+	existing, err := repo.GetBuddy(ctx, character.ID(), target.ID)
+	if err != nil {
+		log.Printf("[Buddy] Failed to load buddy %d of %s: %v", target.ID, character.Name(), err)
+		h.client.Write(packets.FriendMessage(packets.FriendResultUnknown))
+		return
+	}
+	if existing != nil {
+		switch models.BuddyStatus(existing.Status) {
+		case models.BuddyStatusReceived:
+			// Adding someone who already asked is the same as accepting
+			h.acceptFriend(character, target.ID)
+		case models.BuddyStatusFriend:
+			if existing.GroupName == group {
+				h.client.Write(packets.FriendMessage(packets.FriendResultAlreadySet))
+				return
+			}
+			existing.GroupName = group
+			if err := repo.Save(ctx, existing); err != nil {
+				log.Printf("[Buddy] Failed to change group of buddy %d for %s: %v", target.ID, character.Name(), err)
+				h.client.Write(packets.FriendMessage(packets.FriendResultUnknown))
+				return
+			}
+			h.sendFriendList(character, packets.FriendResultSetDone)
+		default:
+			h.client.Write(packets.FriendMessage(packets.FriendResultAlreadySet))
+		}
+		return
+	}
+
+	if count, err := repo.CountListed(ctx, character.ID()); err != nil || int(count) >= character.FriendMax() {
+		h.client.Write(packets.FriendMessage(packets.FriendResultFullMe))
+		return
+	}
+	targetMax := int(target.FriendMax)
+	if targetMax <= 0 {
+		targetMax = packets.DefaultFriendMax
+	}
+	if count, err := repo.CountListed(ctx, target.ID); err != nil || int(count) >= targetMax {
+		h.client.Write(packets.FriendMessage(packets.FriendResultFullOther))
+		return
+	}
+
+	sent := &models.Buddy{
+		CharacterID: character.ID(),
+		BuddyID:     target.ID,
+		BuddyName:   target.Name,
+		GroupName:   group,
+		Status:      byte(models.BuddyStatusSent),
+	}
+	received := &models.Buddy{
+		CharacterID: target.ID,
+		BuddyID:     character.ID(),
+		BuddyName:   character.Name(),
+		GroupName:   models.DefaultBuddyGroup,
+		Status:      byte(models.BuddyStatusReceived),
+	}
+	if err := repo.Save(ctx, sent, received); err != nil {
+		log.Printf("[Buddy] Failed to save buddy request from %s to %s: %v", character.Name(), target.Name, err)
+		h.client.Write(packets.FriendMessage(packets.FriendResultUnknown))
+		return
+	}
+
+	h.sendFriendList(character, packets.FriendResultSetDone)
+	if client, ok := server.GetOnlineClient(target.ID); ok {
+		_ = client.Write(packets.FriendInvite(server.friendOf(&models.Buddy{
+			BuddyID:   character.ID(),
+			BuddyName: character.Name(),
+			GroupName: models.DefaultBuddyGroup,
+		}), int32(character.Level()), int32(character.Job())))
+	}
+
+	log.Printf("[Buddy] %s sent a buddy request to %s", character.Name(), target.Name)
+}
+
+// acceptFriend accepts a buddy request the character received
+func (h *ChannelHandler) acceptFriend(character *field.Character, friendID uint) {
+	server := h.client.server
+	ctx := server.Context()
+	repo := server.Repos().Buddies
+
+	received, err := repo.GetBuddy(ctx, character.ID(), friendID)
+	if err != nil || received == nil || models.BuddyStatus(received.Status) != models.BuddyStatusReceived {
+		h.client.Write(packets.FriendMessage(packets.FriendResultAcceptUnknown))
+		return
+	}
+	sent, err := repo.GetBuddy(ctx, friendID, character.ID())
+	if err != nil || sent == nil {
+		h.client.Write(packets.FriendMessage(packets.FriendResultAcceptUnknown))
+		return
+	}
+	if count, err := repo.CountListed(ctx, character.ID()); err != nil || int(count) >= character.FriendMax() {
+		h.client.Write(packets.FriendMessage(packets.FriendResultFullMe))
+		return
+	}
+
+	received.Status = byte(models.BuddyStatusFriend)
+	sent.Status = byte(models.BuddyStatusFriend)
+	if err := repo.Save(ctx, received, sent); err != nil {
+		log.Printf("[Buddy] Failed to accept buddy %d for %s: %v", friendID, character.Name(), err)
+		h.client.Write(packets.FriendMessage(packets.FriendResultUnknown))
+		return
+	}
+
+	h.sendFriendList(character, packets.FriendResultSetDone)
+	h.refreshFriendList(friendID, packets.FriendResultSetDone)
+
+	log.Printf("[Buddy] %s accepted a buddy request from %s", character.Name(), received.BuddyName)
+}
+
+// deleteFriend removes a buddy, or declines or withdraws a request, for both characters
+func (h *ChannelHandler) deleteFriend(character *field.Character, friendID uint) {
+	server := h.client.server
+	ctx := server.Context()
+	repo := server.Repos().Buddies
+
+	existing, err := repo.GetBuddy(ctx, character.ID(), friendID)
+	if err != nil || existing == nil {
+		h.client.Write(packets.FriendMessage(packets.FriendResultDeleteUnknown))
+		return
+	}
+	if err := repo.Delete(ctx, character.ID(), friendID); err != nil {
+		log.Printf("[Buddy] Failed to delete buddy %d for %s: %v", friendID, character.Name(), err)
+		h.client.Write(packets.FriendMessage(packets.FriendResultUnknown))
+		return
+	}
+
+	h.sendFriendList(character, packets.FriendResultDeleteDone)
+	h.refreshFriendList(friendID, packets.FriendResultDeleteDone)
+
+	log.Printf("[Buddy] %s removed %s from their buddy list", character.Name(), existing.BuddyName)
+}
+
+// refreshFriendList resends the buddy list of another character if they're online
+func (h *ChannelHandler) refreshFriendList(charID uint, result byte) {
+	server := h.client.server
+	client, ok := server.GetOnlineClient(charID)
+	if !ok {
+		return
+	}
+	friends, _, err := server.loadFriends(server.Context(), charID)
+	if err != nil {
+		log.Printf("[Buddy] Failed to load buddies for character %d: %v", charID, err)
+		return
+	}
+	_ = client.Write(packets.FriendList(result, friends))
+}
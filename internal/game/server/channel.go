@@ -29,12 +29,12 @@ type Channel struct {
 }
 
 // NewChannel creates a new channel instance
-func NewChannel(world *World, channelID byte, port int, mapProvider field.MapDataProvider) *Channel {
+func NewChannel(world *World, channelID byte, port int, mapProvider field.MapDataProvider, mobProvider field.MobTemplateProvider) *Channel {
 	return &Channel{
 		world:     world,
 		channelID: channelID,
 		port:      port,
-		fields:    field.NewManager(mapProvider),
+		fields:    field.NewManager(mapProvider, mobProvider),
 		clients:   make(map[uint]*Client),
 	}
 }
@@ -2,11 +2,13 @@ package server
 
 import (
 	"log"
+	"time"
 
 	"github.com/Jinw00Arise/Jinwoo/internal/data/providers"
 	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
 	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
 	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/quest"
 	"github.com/Jinw00Arise/Jinwoo/internal/game/script"
 	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
 )
@@ -14,8 +16,19 @@ import (
 // ChannelHandler handles channel-specific packet processing
 type ChannelHandler struct {
 	client *Client
+
+	// Movement paths rejected by the speed check in the current window
+	moveViolations     int
+	moveViolationSince time.Time
 }
 
+const (
+	// maxMoveViolations is how many over-speed paths a character can send within
+	// moveViolationWindow before they are disconnected
+	maxMoveViolations   = 10
+	moveViolationWindow = time.Minute
+)
+
 // NewChannelHandler creates a new channel handler
 func NewChannelHandler(client *Client) *ChannelHandler {
 	return &ChannelHandler{
@@ -34,10 +47,30 @@ func (h *ChannelHandler) Handle(p protocol.Packet) {
 		h.handleUserMove(reader)
 	case RecvUserChat:
 		h.handleUserChat(reader)
+	case RecvUserMeleeAttack:
+		h.handleUserAttack(reader, field.AttackTypeMelee)
+	case RecvUserShootAttack:
+		h.handleUserAttack(reader, field.AttackTypeShoot)
+	case RecvUserMagicAttack:
+		h.handleUserAttack(reader, field.AttackTypeMagic)
 	case RecvUserPortalScriptRequest:
 		h.handleUserPortalScriptRequest(reader)
 	case RecvUpdateGMBoard:
 		h.handleUpdateGMBoard(reader)
+	case RecvGroupMessage:
+		h.handleGroupMessage(reader)
+	case RecvWhisper:
+		h.handleWhisper(reader)
+	case RecvPartyRequest:
+		h.handlePartyRequest(reader)
+	case RecvPartyResult:
+		h.handlePartyResult(reader)
+	case RecvGuildRequest:
+		h.handleGuildRequest(reader)
+	case RecvGuildResult:
+		h.handleGuildResult(reader)
+	case RecvFriendRequest:
+		h.handleFriendRequest(reader)
 	case RecvCancelInvitePartyMatch:
 		h.handleCancelInvitePartyMatch(reader)
 	case RecvRequireFieldObstacleStatus:
@@ -48,8 +81,42 @@ func (h *ChannelHandler) Handle(p protocol.Packet) {
 		h.handleUserTransferFieldRequest(reader)
 	case RecvNpcMove:
 		h.handleNpcMove(reader)
+	case RecvMobMove:
+		h.handleMobMove(reader)
+	case RecvDropPickUpRequest:
+		h.handleDropPickUpRequest(reader)
 	case RecvUserScriptMessageAnswer:
 		h.handleUserScriptMessageAnswer(reader)
+	case RecvUserChangeSlotPosition:
+		h.handleUserChangeSlotPosition(reader)
+	case RecvUserStatChangeItemUseRequest:
+		h.handleUserStatChangeItemUseRequest(reader)
+	case RecvUserStatChangeItemCancelRequest:
+		h.handleUserStatChangeItemCancelRequest(reader)
+	case RecvUserPortalScrollUseRequest:
+		h.handleUserPortalScrollUseRequest(reader)
+	case RecvUserUpgradeItemUseRequest:
+		h.handleUserUpgradeItemUseRequest(reader)
+	case RecvUserItemReleaseRequest:
+		h.handleUserItemReleaseRequest(reader)
+	case RecvUserConsumeCashItemUseRequest:
+		h.handleUserConsumeCashItemUseRequest(reader)
+	case RecvUserAbilityUpRequest:
+		h.handleUserAbilityUpRequest(reader)
+	case RecvUserAbilityMassUpRequest:
+		h.handleUserAbilityMassUpRequest(reader)
+	case RecvUserSkillUpRequest:
+		h.handleUserSkillUpRequest(reader)
+	case RecvUserSkillUseRequest:
+		h.handleUserSkillUseRequest(reader)
+	case RecvUserSkillCancelRequest:
+		h.handleUserSkillCancelRequest(reader)
+	case RecvUserQuestRequest:
+		h.handleUserQuestRequest(reader)
+	case RecvUserMacroSysDataModified:
+		h.handleUserMacroSysDataModified(reader)
+	case RecvUserSelectNpc:
+		h.handleUserSelectNpc(reader)
 	default:
 		log.Printf("[Channel] Unhandled opcode: 0x%04X (%d)", reader.Opcode, reader.Opcode)
 	}
@@ -59,6 +126,20 @@ func (h *ChannelHandler) Handle(p protocol.Packet) {
 func (h *ChannelHandler) OnDisconnect() {
 	// Clean up character from field
 	if h.client.character != nil {
+		// Unblock any script still waiting on a dialog answer
+		h.client.server.ScriptManager().Conversations().EndConversation(h.client.character.ID())
+
+		// Migrating clients were already saved by ChangeChannel
+		if h.client.State() != ClientStateMigrating {
+			if err := h.client.server.SaveCharacter(h.client.character); err != nil {
+				log.Printf("[Channel] %v", err)
+			}
+			if channel := h.client.Channel(); channel != nil {
+				channel.World().SetPartyMemberOffline(h.client.character)
+				channel.World().SetGuildMemberOffline(h.client.character)
+			}
+		}
+
 		currentField := h.client.character.Field()
 		if currentField != nil {
 			currentField.RemoveCharacter(h.client.character)
@@ -95,6 +176,11 @@ func (h *ChannelHandler) sendFieldEntities(character *field.Character, targetFie
 	}
 	targetField.AssignControllerToMobs(character)
 
+	// Send drops already on the ground
+	for _, drop := range targetField.GetVisibleDrops(character) {
+		character.Write(packets.DropEnterField(drop, packets.DropEnterOnFoothold, 0))
+	}
+
 	// Send other characters
 	for _, otherChar := range targetField.GetAllCharacters() {
 		if otherChar.ID() != character.ID() {
@@ -104,6 +190,11 @@ func (h *ChannelHandler) sendFieldEntities(character *field.Character, targetFie
 
 	// Broadcast entry to others
 	targetField.BroadcastExcept(UserEnterField(character), character)
+
+	// Party members see the new channel and map, and swap HP bars
+	channel := h.client.Channel()
+	channel.World().UpdatePartyMember(character, channel.ID())
+	character.ShowPartyHP()
 }
 
 func (h *ChannelHandler) handleMigrateIn(reader *protocol.Reader) {
@@ -180,12 +271,41 @@ func (h *ChannelHandler) handleMigrateIn(reader *protocol.Reader) {
 		}
 	}
 
+	// Load skills, macros and cooldowns
+	var skills []*models.Skill
+	var macros []*models.SkillMacro
+	var cooldowns []*models.SkillCooldown
+	if skillRepo := server.Repos().Skills; skillRepo != nil {
+		if skills, err = skillRepo.GetSkills(ctx, uint(characterID)); err != nil {
+			log.Printf("[Channel] Failed to load skills for character %d: %v", characterID, err)
+			h.client.Close()
+			return
+		}
+		if macros, err = skillRepo.GetMacros(ctx, uint(characterID)); err != nil {
+			log.Printf("[Channel] Failed to load macros for character %d: %v", characterID, err)
+			// Non-fatal, continue without macros
+		}
+		if cooldowns, err = skillRepo.GetCooldowns(ctx, uint(characterID)); err != nil {
+			log.Printf("[Channel] Failed to load cooldowns for character %d: %v", characterID, err)
+			// Non-fatal, continue without cooldowns
+		}
+	}
+
 	// Create user session and character instance
 	user := field.NewUser(h.client.conn, account.ID)
-	character := field.NewCharacter(user, char)
+	character := field.NewCharacter(user, char, server.ItemProvider())
 	user.SetCharacter(character)
 	character.SetItems(items)
 	character.SetQuestRecords(questRecords)
+	character.SetSkills(skills)
+	character.SetMacros(macros)
+	character.SetCooldowns(cooldowns)
+
+	if questProvider := server.QuestProvider(); questProvider != nil {
+		questManager := quest.NewCharacterQuestManager(char.ID, questProvider)
+		questManager.LoadFromDB(questRecords, nil)
+		character.SetQuestManager(questManager)
+	}
 
 	h.client.SetUser(user)
 	h.client.SetCharacter(character)
@@ -198,6 +318,9 @@ func (h *ChannelHandler) handleMigrateIn(reader *protocol.Reader) {
 	// Add to world tracking
 	world := channel.World()
 	world.AddCharacter(uint(characterID), char.Name, channel.ID())
+	if server.Repos().Guilds != nil {
+		world.LoginGuildMember(ctx, character)
+	}
 
 	// Add to channel client list
 	channel.AddClient(uint(characterID), h.client)
@@ -231,13 +354,27 @@ func (h *ChannelHandler) handleMigrateIn(reader *protocol.Reader) {
 	log.Printf("[Channel] Player %s (id=%d) entering game at (%d, %d)", char.Name, char.ID, posX, posY)
 
 	// Send SetField packet
-	if err := h.client.Write(SetField(char, int(channel.ID()), character.FieldKey(), items, questRecords)); err != nil {
+	if err := h.client.Write(SetField(character, int(channel.ID()))); err != nil {
 		log.Printf("[Channel] Failed to send SetField: %v", err)
 		targetField.RemoveCharacter(character)
 		h.client.Close()
 		return
 	}
 
+	// HP and MP loaded above the equipment's max are cut back now the client has the field
+	character.RecalcStats()
+
+	h.client.Write(packets.MacroSysDataInit(character.Macros()))
+	h.sendBuddiesOnLogin(character)
+	if character.GuildID() != 0 {
+		h.sendGuild(character)
+	}
+
+	// Chat sent to the character while they were changing channels
+	for _, p := range server.TakePendingPackets(character.ID()) {
+		h.client.Write(p)
+	}
+
 	// Send field entities (NPCs, mobs, other characters)
 	h.sendFieldEntities(character, targetField)
 
@@ -269,6 +406,11 @@ func (h *ChannelHandler) handleUserMove(reader *protocol.Reader) {
 	_ = reader.ReadInt() // crc32
 
 	movePath := field.DecodeMovePath(reader)
+	// Drop paths faster than the character's speed; they stay at the last valid position
+	if speed := character.CalcStats().Speed; !movePath.WithinSpeed(speed) {
+		h.recordMoveViolation(character, speed)
+		return
+	}
 	movePath.ApplyTo(character)
 
 	// Broadcast movement to other characters
@@ -278,6 +420,24 @@ func (h *ChannelHandler) handleUserMove(reader *protocol.Reader) {
 	}
 }
 
+// recordMoveViolation counts a rejected movement path and disconnects characters that
+// keep sending them
+func (h *ChannelHandler) recordMoveViolation(character *field.Character, speed int32) {
+	now := time.Now()
+	if now.Sub(h.moveViolationSince) > moveViolationWindow {
+		h.moveViolations = 0
+		h.moveViolationSince = now
+	}
+	h.moveViolations++
+
+	log.Printf("[Channel] SECURITY: %s moved faster than speed %d allows, rejecting path (%d in window)",
+		character.Name(), speed, h.moveViolations)
+	if h.moveViolations >= maxMoveViolations {
+		log.Printf("[Channel] SECURITY: Disconnecting %s after %d over-speed paths", character.Name(), h.moveViolations)
+		h.client.Close()
+	}
+}
+
 func (h *ChannelHandler) handleUserChat(reader *protocol.Reader) {
 	character := h.client.character
 	if character == nil {
@@ -343,9 +503,7 @@ func (h *ChannelHandler) sendPortalScript(character *field.Character, portal pro
 			character.TransferToField(targetField, portal.TN)
 
 			// Send SetField packet
-			items := character.Items()
-			quests := character.QuestRecords()
-			if err := h.client.Write(SetField(character.Model(), int(channel.ID()), character.FieldKey(), items, quests)); err != nil {
+			if err := h.client.Write(SetField(character, int(channel.ID()))); err != nil {
 				log.Printf("[Channel] Failed to send SetField after portal warp: %v", err)
 			}
 
@@ -452,9 +610,7 @@ func (h *ChannelHandler) handleUserTransferFieldRequest(reader *protocol.Reader)
 			}
 			char.TransferToField(targetField, "")
 
-			items := char.Items()
-			quests := char.QuestRecords()
-			if err := h.client.Write(SetField(char.Model(), int(h.client.channel.ID()), char.FieldKey(), items, quests)); err != nil {
+			if err := h.client.Write(SetField(char, int(h.client.channel.ID()))); err != nil {
 				log.Printf("[Channel] Failed to send SetField: %v", err)
 			}
 
@@ -467,7 +623,7 @@ func (h *ChannelHandler) handleUserTransferFieldRequest(reader *protocol.Reader)
 	// Find the portal on current map
 	portal, exists := currentField.GetPortal(portalName)
 	if !exists {
-		log.Printf("[Transfer] Portal '%s' not found on map %d", portalName, char.MapID)
+		log.Printf("[Transfer] Portal '%s' not found on map %d", portalName, char.MapID())
 		h.client.Write(packets.EnableActions())
 		return
 	}
@@ -482,9 +638,7 @@ func (h *ChannelHandler) handleUserTransferFieldRequest(reader *protocol.Reader)
 
 	char.TransferToField(targetField, portal.TN)
 
-	items := char.Items()
-	quests := char.QuestRecords()
-	if err := h.client.Write(SetField(char.Model(), int(h.client.channel.ID()), char.FieldKey(), items, quests)); err != nil {
+	if err := h.client.Write(SetField(char, int(h.client.channel.ID()))); err != nil {
 		log.Printf("[Channel] Failed to send SetField after portal warp: %v", err)
 	}
 
@@ -57,6 +57,13 @@ type Client struct {
 	// Handler references
 	loginHandler   *LoginHandler
 	channelHandler *ChannelHandler
+
+	// Work queued by other goroutines, run between packets
+	tasks   []func()
+	tasksMu sync.Mutex
+	closed  bool
+	wake    chan struct{}
+	done    chan struct{}
 }
 
 // NewClient creates a new client instance
@@ -66,6 +73,8 @@ func NewClient(server *Server, conn *network.Connection, clientType ClientType)
 		conn:       conn,
 		clientType: clientType,
 		state:      ClientStateConnected,
+		wake:       make(chan struct{}, 1),
+		done:       make(chan struct{}),
 	}
 
 	// Create appropriate handler
@@ -240,20 +249,103 @@ func (c *Client) SetOpcodeNames() {
 	}
 }
 
-// HandlePackets is the main packet processing loop
+// HandlePackets is the main packet processing loop. Tasks queued with Do run on
+// this goroutine between packets, so they never race the packet handlers.
 func (c *Client) HandlePackets() {
-	defer c.onDisconnect()
+	defer func() {
+		c.runQueuedTasks()
+		c.onDisconnect()
+
+		c.tasksMu.Lock()
+		c.closed = true
+		c.tasks = nil
+		c.tasksMu.Unlock()
+		close(c.done)
+	}()
+
+	incoming := make(chan protocol.Packet)
+	readErr := make(chan error, 1)
+	go func() {
+		for {
+			p, err := c.conn.Read()
+			if err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case incoming <- p:
+			case <-c.done:
+				return
+			}
+		}
+	}()
 
 	for {
-		p, err := c.conn.Read()
-		if err != nil {
+		select {
+		case p := <-incoming:
+			c.handlePacket(p)
+		case <-c.wake:
+			c.runQueuedTasks()
+		case err := <-readErr:
 			if err.Error() != "EOF" {
 				log.Printf("Read error: %v", err)
 			}
 			return
 		}
+	}
+}
+
+// Do queues fn to run on the client's packet goroutine without waiting for it.
+// Returns false if the client has already disconnected.
+func (c *Client) Do(fn func()) bool {
+	c.tasksMu.Lock()
+	if c.closed {
+		c.tasksMu.Unlock()
+		return false
+	}
+	c.tasks = append(c.tasks, fn)
+	c.tasksMu.Unlock()
 
-		c.handlePacket(p)
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// Call runs fn on the client's packet goroutine and waits for it to finish.
+// Returns false if the client disconnected before fn ran. Never call it from
+// the client's own goroutine.
+func (c *Client) Call(fn func()) bool {
+	finished := make(chan struct{})
+	if !c.Do(func() {
+		fn()
+		close(finished)
+	}) {
+		return false
+	}
+	select {
+	case <-finished:
+		return true
+	case <-c.done:
+		select {
+		case <-finished:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// runQueuedTasks runs the tasks queued so far
+func (c *Client) runQueuedTasks() {
+	c.tasksMu.Lock()
+	tasks := c.tasks
+	c.tasks = nil
+	c.tasksMu.Unlock()
+
+	for _, fn := range tasks {
+		fn()
 	}
 }
 
@@ -313,6 +405,11 @@ func (c *Client) ChangeChannel(targetChannelID byte) error {
 		return nil
 	}
 
+	// Persist before the target channel loads the character from the database
+	if err := c.server.SaveCharacter(c.character); err != nil {
+		return err
+	}
+
 	// Remove from current field
 	if c.character != nil {
 		currentField := c.character.Field()
@@ -3,6 +3,7 @@ package server
 import (
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -35,6 +36,11 @@ type Config struct {
 	DebugPackets bool
 	AutoRegister bool
 
+	// Secondary password (PIC)
+	PICEnabled     bool
+	PICMaxAttempts int           // Failed PIC checks before the account is locked
+	PICLockout     time.Duration // How long a locked account stays locked
+
 	// Game version
 	GameVersion  uint16
 	PatchVersion string
@@ -52,6 +58,9 @@ type Config struct {
 	QuestExpRate float64
 	MesoRate     float64
 	DropRate     float64
+
+	// Persistence
+	AutosaveInterval time.Duration // 0 disables periodic autosave
 }
 
 // Load loads the server configuration from environment variables
@@ -64,6 +73,7 @@ func Load() *Config {
 		DatabaseURL:  getEnv("DATABASE_URL", "postgres://localhost:5432/jinwoo?sslmode=disable"),
 		DebugPackets: getEnv("DEBUG_PACKETS", "") != "",
 		AutoRegister: getEnv("AUTO_REGISTER", "true") != "",
+		PICEnabled:   getEnvBool("PIC_ENABLED", false),
 		GameVersion:  95,
 		PatchVersion: "1",
 		Locale:       8,
@@ -73,6 +83,10 @@ func Load() *Config {
 		QuestExpRate: getEnvFloat("QUEST_EXP_RATE", 1.0),
 		MesoRate:     getEnvFloat("MESO_RATE", 1.0),
 		DropRate:     getEnvFloat("DROP_RATE", 1.0),
+
+		AutosaveInterval: time.Duration(getEnvInt("AUTOSAVE_INTERVAL", 300)) * time.Second,
+		PICMaxAttempts:   getEnvInt("PIC_MAX_ATTEMPTS", 5),
+		PICLockout:       time.Duration(getEnvInt("PIC_LOCKOUT", 600)) * time.Second,
 	}
 
 	// Build worlds configuration
@@ -138,6 +152,15 @@ func getEnvInt(key string, fallback int) int {
 	return fallback
 }
 
+func getEnvBool(key string, fallback bool) bool {
+	if val := os.Getenv(key); val != "" {
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
 func getEnvFloat(key string, fallback float64) float64 {
 	if val := os.Getenv(key); val != "" {
 		if f, err := strconv.ParseFloat(val, 64); err == nil {
@@ -0,0 +1,280 @@
+package server
+
+import (
+	"log"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers/item"
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+	"github.com/Jinw00Arise/Jinwoo/internal/utils"
+)
+
+const (
+	// dropPickupRange is how far from a drop a character may pick it up, with
+	// room for the server-side position lagging behind the client
+	dropPickupRange = 300
+
+	// dropSpread is the horizontal gap between drops from the same source
+	dropSpread = 25
+
+	// Default meso drop for mobs without a meso entry in the drop table
+	defaultMesoChance = 0.6
+
+	// potentialDropChance is how likely a dropped equip comes with hidden potential
+	potentialDropChance = 0.08
+)
+
+func (h *ChannelHandler) handleDropPickUpRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	currentField := character.Field()
+	if currentField == nil {
+		return
+	}
+
+	_ = reader.ReadByte()  // bFieldKey
+	_ = reader.ReadInt()   // update time
+	_ = reader.ReadShort() // pt.x
+	_ = reader.ReadShort() // pt.y
+	objectID := reader.ReadInt()
+	_ = reader.ReadInt() // dwCliCrc
+
+	// An empty inventory operation releases the client's pickup lock
+	fail := func() {
+		h.client.Write(packets.InventoryOperationPacket(true, nil))
+	}
+
+	drop := currentField.GetDrop(objectID)
+	if drop == nil {
+		fail()
+		return
+	}
+	if !inDropRange(character, drop) {
+		log.Printf("[Drop] %s tried to pick up drop %d out of range", character.Name(), objectID)
+		fail()
+		return
+	}
+	if !drop.CanPickUp(character, time.Now()) || !drop.Claim() {
+		fail()
+		return
+	}
+
+	if drop.IsMoney() {
+		if int64(character.Mesos())+int64(drop.Mesos()) > math.MaxInt32 {
+			drop.Release()
+			fail()
+			return
+		}
+		character.GainMesos(drop.Mesos())
+		character.Write(packets.MessageDropPickupMesos(drop.Mesos()))
+		h.client.Write(packets.EnableActions())
+	} else {
+		it := drop.Item()
+		quantity := it.Quantity
+		if h.isOneOfAKind(it.ItemID) && character.HasItem(it.ItemID) {
+			drop.Release()
+			fail()
+			return
+		}
+		if !character.AddItem(it) {
+			drop.Release()
+			fail()
+			return
+		}
+		character.Write(packets.MessageDropPickup(it.ItemID, int32(quantity)))
+	}
+
+	currentField.RemoveDrop(objectID, packets.DropLeavePickedUpByUser, character.ID())
+}
+
+// dropInventoryItem drops an item from the character's inventory onto the field
+func (h *ChannelHandler) dropInventoryItem(character *field.Character, invType models.InventoryType, slot, count int16) {
+	currentField := character.Field()
+	if currentField == nil {
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	it := character.ItemAt(invType, slot)
+	if it == nil || h.itemFlag(it.ItemID, item.KeyDropBlock) {
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	taken, err := character.TakeItem(invType, slot, count)
+	if err != nil {
+		log.Printf("[Drop] %s: drop from slot %d failed: %v", character.Name(), slot, err)
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	// Untradeable items vanish instead of landing on the ground
+	if h.itemFlag(taken.ItemID, item.KeyTradeBlock) || h.itemFlag(taken.ItemID, item.KeyQuest) {
+		return
+	}
+
+	x, y := character.Position()
+	drop := field.NewItemDrop(currentField.NextObjectID(), taken, x, y)
+	drop.SetOwner(character.ID(), 0, field.DropOwnTypeFreeForAll)
+	drop.SetSource(0, x, y, true)
+	currentField.AddDrop(drop, 0)
+}
+
+// itemFlag returns true if a boolean item info flag such as tradeBlock is set
+func (h *ChannelHandler) itemFlag(itemID int32, key item.ItemInfosKey) bool {
+	provider := h.client.server.ItemProvider()
+	if provider == nil {
+		return false
+	}
+	info := provider.GetItemInfo(itemID)
+	return info != nil && info.GetInfoFlag(key)
+}
+
+// isOneOfAKind returns true for items a character may only hold one of
+func (h *ChannelHandler) isOneOfAKind(itemID int32) bool {
+	return h.itemFlag(itemID, item.KeyOnly)
+}
+
+// rolledDrop is a drop table result waiting to be placed on the field
+type rolledDrop struct {
+	item    *models.CharacterItem // nil for mesos
+	mesos   int32
+	questID int32
+}
+
+// spawnMobDrops rolls a dead mob's drop table and puts the results on the field,
+// owned by the character that dealt the most damage
+func (c *Channel) spawnMobDrops(f *field.Field, mob *field.Mob, owner *field.Character) {
+	server := c.Server()
+	cfg := server.Config()
+
+	var rolled []rolledDrop
+	hasMesoEntry := false
+	for _, entry := range server.DropTable().MobDrops(mob.TemplateID()) {
+		if entry.ItemID == 0 {
+			hasMesoEntry = true
+		}
+		if entry.QuestID != 0 && owner.GetQuestState(uint16(entry.QuestID)) != models.QuestStatePerform {
+			continue
+		}
+		if !rollDropChance(entry.Chance, cfg.DropRate) {
+			continue
+		}
+
+		quantity := entry.MinQuantity
+		if entry.MaxQuantity > entry.MinQuantity {
+			quantity += rand.Int32N(entry.MaxQuantity - entry.MinQuantity + 1)
+		}
+		if quantity <= 0 {
+			continue
+		}
+
+		if entry.ItemID == 0 {
+			rolled = append(rolled, rolledDrop{mesos: scaleMesos(quantity, cfg.MesoRate)})
+			continue
+		}
+		rolled = append(rolled, rolledDrop{item: c.newDropItem(entry.ItemID, int16(quantity)), questID: entry.QuestID})
+	}
+
+	if !hasMesoEntry && rand.Float64() < defaultMesoChance {
+		if mesos := defaultMobMesos(mob.Level()); mesos > 0 {
+			rolled = append(rolled, rolledDrop{mesos: scaleMesos(mesos, cfg.MesoRate)})
+		}
+	}
+
+	ownType := field.DropOwnTypeUser
+	if owner.PartyID() != 0 {
+		ownType = field.DropOwnTypeParty
+	}
+
+	rand.Shuffle(len(rolled), func(i, j int) { rolled[i], rolled[j] = rolled[j], rolled[i] })
+
+	mx, my := mob.GetX(), mob.GetY()
+	for i, r := range rolled {
+		// Fan drops out around the mob: 0, -1, +1, -2, +2, ...
+		offset := int16((i + 1) / 2 * dropSpread)
+		if i%2 == 1 {
+			offset = -offset
+		}
+		x := uint16(int16(mx) + offset)
+
+		var drop *field.Drop
+		if r.item == nil {
+			drop = field.NewMesoDrop(f.NextObjectID(), r.mesos, x, my)
+		} else {
+			drop = field.NewItemDrop(f.NextObjectID(), r.item, x, my)
+			drop.SetQuest(r.questID)
+		}
+		drop.SetOwner(owner.ID(), owner.PartyID(), ownType)
+		drop.SetSource(mob.ObjectID(), mx, my, false)
+		f.AddDrop(drop, 0)
+	}
+}
+
+// newDropItem creates the item instance for a rolled drop
+func (c *Channel) newDropItem(itemID int32, quantity int16) *models.CharacterItem {
+	invType := utils.GetInventoryTypeByItemID(itemID)
+	if invType == models.InvEquip {
+		if provider := c.Server().ItemProvider(); provider != nil {
+			if it := utils.NewEquipFromItemInfo(provider.GetItemInfo(itemID), invType, 0); it != nil {
+				if rand.Float64() < potentialDropChance {
+					field.AddHiddenPotential(it)
+				}
+				return it
+			}
+		}
+		quantity = 1
+	}
+	return &models.CharacterItem{InvType: invType, ItemID: itemID, Quantity: quantity}
+}
+
+// topAttacker returns the character in the field that dealt the most damage to a mob
+func topAttacker(f *field.Field, attackers map[uint]int64) *field.Character {
+	var top *field.Character
+	var best int64
+	for charID, dmg := range attackers {
+		if dmg <= best {
+			continue
+		}
+		if char := f.GetCharacter(charID); char != nil {
+			top, best = char, dmg
+		}
+	}
+	return top
+}
+
+// rollDropChance rolls a drop entry's chance, scaled by the server drop rate
+func rollDropChance(chance int32, rate float64) bool {
+	return rand.Float64()*models.DropChanceMax < float64(chance)*rate
+}
+
+// scaleMesos applies the server meso rate to a meso drop
+func scaleMesos(mesos int32, rate float64) int32 {
+	return max(int32(float64(mesos)*rate), 1)
+}
+
+// defaultMobMesos returns a meso amount for a mob of the given level
+func defaultMobMesos(level int32) int32 {
+	if level <= 0 {
+		return 0
+	}
+	base := level * 3
+	return base + rand.Int32N(level*2+1)
+}
+
+// inDropRange returns true if the character is close enough to pick up the drop
+func inDropRange(character *field.Character, drop *field.Drop) bool {
+	cx, cy := character.Position()
+	dx0, dy0 := drop.Position()
+	dx := int(int16(cx)) - int(int16(dx0))
+	dy := int(int16(cy)) - int(int16(dy0))
+	return dx*dx+dy*dy <= dropPickupRange*dropPickupRange
+}
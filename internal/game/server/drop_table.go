@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/interfaces"
+)
+
+// DropTable caches the mob and reactor drop entries loaded from the database
+type DropTable struct {
+	mu       sync.RWMutex
+	mobs     map[int32][]*models.DropEntry
+	reactors map[int32][]*models.DropEntry
+}
+
+// NewDropTable creates an empty drop table
+func NewDropTable() *DropTable {
+	return &DropTable{
+		mobs:     make(map[int32][]*models.DropEntry),
+		reactors: make(map[int32][]*models.DropEntry),
+	}
+}
+
+// Load replaces the cached entries with the ones stored in the database
+func (t *DropTable) Load(ctx context.Context, repo interfaces.DropRepo) error {
+	entries, err := repo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	mobs := make(map[int32][]*models.DropEntry)
+	reactors := make(map[int32][]*models.DropEntry)
+	for _, e := range entries {
+		switch e.SourceType {
+		case models.DropSourceMob:
+			mobs[e.SourceID] = append(mobs[e.SourceID], e)
+		case models.DropSourceReactor:
+			reactors[e.SourceID] = append(reactors[e.SourceID], e)
+		}
+	}
+
+	t.mu.Lock()
+	t.mobs = mobs
+	t.reactors = reactors
+	t.mu.Unlock()
+	return nil
+}
+
+// MobDrops returns the drop entries of a mob template
+func (t *DropTable) MobDrops(mobID int32) []*models.DropEntry {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.mobs[mobID]
+}
+
+// ReactorDrops returns the drop entries of a reactor template
+func (t *DropTable) ReactorDrops(reactorID int32) []*models.DropEntry {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.reactors[reactorID]
+}
+
+// Count returns the number of mobs and reactors with drops
+func (t *DropTable) Count() (mobs, reactors int) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.mobs), len(t.reactors)
+}
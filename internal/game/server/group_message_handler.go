@@ -0,0 +1,95 @@
+package server
+
+import (
+	"log"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+// handleGroupMessage handles buddy, party and guild chat. Lines are delivered through
+// the server's online registry, so recipients on any channel of the world get them.
+func (h *ChannelHandler) handleGroupMessage(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	_ = reader.ReadInt() // update time
+	messageType := reader.ReadByte()
+	count := int(reader.ReadByte())
+	recipients := make([]uint, 0, count)
+	for i := 0; i < count; i++ {
+		recipients = append(recipients, uint(reader.ReadInt()))
+	}
+	text := reader.ReadString()
+
+	var targets []uint
+	switch messageType {
+	case packets.GroupMessageBuddy:
+		targets = h.buddyChatTargets(character, recipients)
+	case packets.GroupMessageParty:
+		targets = h.partyChatTargets(character)
+	case packets.GroupMessageGuild:
+		targets = h.guildChatTargets(character)
+	default:
+		log.Printf("[Chat] Unhandled group message type %d from %s", messageType, character.Name())
+		return
+	}
+
+	p := packets.GroupMessage(messageType, character.Name(), text)
+	server := h.client.server
+	for _, charID := range targets {
+		if charID != character.ID() {
+			server.SendToCharacter(charID, p)
+		}
+	}
+}
+
+// buddyChatTargets returns the recipients the client picked that are accepted buddies of the sender
+func (h *ChannelHandler) buddyChatTargets(character *field.Character, recipients []uint) []uint {
+	server := h.client.server
+	if server.Repos().Buddies == nil {
+		return nil
+	}
+
+	buddies, err := server.Repos().Buddies.GetBuddies(server.Context(), character.ID())
+	if err != nil {
+		log.Printf("[Buddy] Failed to load buddies for %s: %v", character.Name(), err)
+		return nil
+	}
+	friends := make(map[uint]bool, len(buddies))
+	for _, buddy := range buddies {
+		if models.BuddyStatus(buddy.Status) == models.BuddyStatusFriend {
+			friends[buddy.BuddyID] = true
+		}
+	}
+
+	var targets []uint
+	for _, charID := range recipients {
+		if friends[charID] {
+			targets = append(targets, charID)
+		}
+	}
+	return targets
+}
+
+// partyChatTargets returns every member of the sender's party
+func (h *ChannelHandler) partyChatTargets(character *field.Character) []uint {
+	party, ok := h.client.Channel().World().GetParty(character.PartyID())
+	if !ok {
+		return nil
+	}
+	return party.memberIDs()
+}
+
+// guildChatTargets returns every member of the sender's guild
+func (h *ChannelHandler) guildChatTargets(character *field.Character) []uint {
+	guild, _, ok := h.currentGuild(character)
+	if !ok {
+		return nil
+	}
+	return guild.memberIDs()
+}
@@ -0,0 +1,515 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+	"gorm.io/gorm"
+)
+
+const (
+	guildCreateCost      int32 = 1500000
+	guildMarkCost        int32 = 5000000
+	guildCapacityStep    int32 = 5
+	guildMaxCapacity     int32 = 100
+	guildCapacityCost    int32 = 500000 // Per step already bought
+	guildInviteTimeout         = time.Minute
+	guildDialogTimeout         = 5 * time.Minute
+	guildDefaultCapacity int32 = 10
+)
+
+// Default rank titles of a new guild, from master down
+var guildDefaultGradeNames = [5]string{"Master", "Jr. Master", "Member", "Member", "Member"}
+
+// guildInvite is an invite waiting for the invited character's answer
+type guildInvite struct {
+	guildID   uint
+	inviterID uint
+	expires   time.Time
+}
+
+// guildDialog is a guild name or emblem dialog opened by an NPC. The client's
+// answer is only accepted while it's open.
+type guildDialog struct {
+	result  byte // packets.GuildResultInputGuildName or packets.GuildResultInputMark
+	expires time.Time
+}
+
+// Guild is a world-wide group of characters. Guilds are stored in the database and
+// cached with their member list the first time a member logs in; every change is
+// written through.
+type Guild struct {
+	model   *models.Guild
+	members []packets.GuildMember
+	mu      sync.RWMutex
+}
+
+// ID returns the guild ID
+func (g *Guild) ID() uint {
+	return g.model.ID
+}
+
+// Name returns the guild name
+func (g *Guild) Name() string {
+	return g.model.Name
+}
+
+// Mark returns the guild emblem
+func (g *Guild) Mark() packets.GuildMark {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return packets.GuildMark{
+		Bg:      g.model.MarkBg,
+		BgColor: g.model.MarkBgColor,
+		Mark:    g.model.Mark,
+		Color:   g.model.MarkColor,
+	}
+}
+
+// Capacity returns how many members fit in the guild
+func (g *Guild) Capacity() int32 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.model.Capacity
+}
+
+// IsFull reports whether the guild has no room for another member
+func (g *Guild) IsFull() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return int32(len(g.members)) >= g.model.Capacity
+}
+
+// Member returns a member's guild window entry
+func (g *Guild) Member(charID uint) (packets.GuildMember, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for _, m := range g.members {
+		if m.CharacterID == charID {
+			return m, true
+		}
+	}
+	return packets.GuildMember{}, false
+}
+
+func (g *Guild) memberIDs() []uint {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	ids := make([]uint, 0, len(g.members))
+	for _, m := range g.members {
+		ids = append(ids, m.CharacterID)
+	}
+	return ids
+}
+
+func (g *Guild) addMember(member packets.GuildMember) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.members = append(g.members, member)
+}
+
+func (g *Guild) removeMember(charID uint) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i, m := range g.members {
+		if m.CharacterID == charID {
+			g.members = slices.Delete(g.members, i, i+1)
+			return true
+		}
+	}
+	return false
+}
+
+// updateMember replaces a member's entry, returning false if they aren't a member
+func (g *Guild) updateMember(member packets.GuildMember) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i, m := range g.members {
+		if m.CharacterID == member.CharacterID {
+			g.members[i] = member
+			return true
+		}
+	}
+	return false
+}
+
+// update changes the guild model under the lock and writes it to the database
+func (g *Guild) update(ctx context.Context, w *World, fn func(model *models.Guild)) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	updated := *g.model
+	fn(&updated)
+	if err := w.server.repos.Guilds.Update(ctx, &updated); err != nil {
+		return err
+	}
+	*g.model = updated
+	return nil
+}
+
+// guildMemberOf builds a guild window entry for a character
+func guildMemberOf(character *field.Character, grade byte) packets.GuildMember {
+	return packets.GuildMember{
+		CharacterID: character.ID(),
+		Name:        character.Name(),
+		Job:         character.Job(),
+		Level:       character.Level(),
+		Grade:       grade,
+	}
+}
+
+// GetGuild returns a cached guild
+func (w *World) GetGuild(guildID uint) (*Guild, bool) {
+	w.guildsMu.RLock()
+	defer w.guildsMu.RUnlock()
+	guild, ok := w.guilds[guildID]
+	return guild, ok
+}
+
+// LoadGuild returns a guild, reading it and its members from the database if it
+// isn't cached yet. A guild that no longer exists returns nil without an error.
+func (w *World) LoadGuild(ctx context.Context, guildID uint) (*Guild, error) {
+	if guild, ok := w.GetGuild(guildID); ok {
+		return guild, nil
+	}
+
+	w.guildsMu.Lock()
+	defer w.guildsMu.Unlock()
+	if guild, ok := w.guilds[guildID]; ok {
+		return guild, nil
+	}
+
+	repo := w.server.repos.Guilds
+	model, err := repo.FindByID(ctx, guildID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	chars, err := repo.GetMembers(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	guild := &Guild{model: model}
+	for _, char := range chars {
+		guild.members = append(guild.members, packets.GuildMember{
+			CharacterID: char.ID,
+			Name:        char.Name,
+			Job:         char.Job,
+			Level:       int16(char.Level),
+			Grade:       char.GuildGrade,
+		})
+	}
+	w.guilds[guildID] = guild
+	return guild, nil
+}
+
+// CreateGuild makes a new guild with the character as its master
+func (w *World) CreateGuild(ctx context.Context, character *field.Character, name string) (*Guild, error) {
+	model := &models.Guild{
+		WorldID:  w.worldID,
+		Name:     name,
+		MasterID: character.ID(),
+		Capacity: guildDefaultCapacity,
+	}
+	model.SetGradeNames(guildDefaultGradeNames)
+	if err := w.server.repos.Guilds.Create(ctx, model); err != nil {
+		return nil, err
+	}
+
+	guild := &Guild{
+		model:   model,
+		members: []packets.GuildMember{guildMemberOf(character, models.GuildGradeMaster)},
+	}
+	w.guildsMu.Lock()
+	w.guilds[model.ID] = guild
+	w.guildsMu.Unlock()
+
+	character.SetGuild(model.ID, models.GuildGradeMaster)
+	w.ShowGuild(character, guild)
+	return guild, nil
+}
+
+// RemoveGuild disbands a guild and takes it away from its online members. Offline
+// members were cleared in the database.
+func (w *World) RemoveGuild(ctx context.Context, guild *Guild) error {
+	if err := w.server.repos.Guilds.Delete(ctx, guild.ID()); err != nil {
+		return err
+	}
+
+	w.guildsMu.Lock()
+	delete(w.guilds, guild.ID())
+	w.guildsMu.Unlock()
+
+	p := packets.GuildRemoved(guild.ID())
+	for _, charID := range guild.memberIDs() {
+		client, ok := w.GetClient(charID)
+		if !ok {
+			continue
+		}
+		if character := client.Character(); character != nil {
+			character.SetGuild(0, 0)
+			w.ShowGuild(character, nil)
+		}
+		_ = client.Write(p)
+	}
+	return nil
+}
+
+// GuildData returns a snapshot of the guild window with each member's online state
+func (w *World) GuildData(guild *Guild) packets.GuildData {
+	guild.mu.RLock()
+	defer guild.mu.RUnlock()
+
+	members := slices.Clone(guild.members)
+	for i := range members {
+		members[i].Online = w.server.IsCharacterOnline(members[i].CharacterID)
+	}
+	return packets.GuildData{
+		ID:         guild.model.ID,
+		Name:       guild.model.Name,
+		GradeNames: guild.model.GradeNames(),
+		Members:    members,
+		Capacity:   guild.model.Capacity,
+		Mark: packets.GuildMark{
+			Bg:      guild.model.MarkBg,
+			BgColor: guild.model.MarkBgColor,
+			Mark:    guild.model.Mark,
+			Color:   guild.model.MarkColor,
+		},
+		Notice: guild.model.Notice,
+		Points: guild.model.Points,
+	}
+}
+
+// ShowGuild sets the guild name and emblem over a character's head, shows them to
+// the rest of the field and sends the character their guild window. A nil guild
+// clears all three.
+func (w *World) ShowGuild(character *field.Character, guild *Guild) {
+	if guild == nil {
+		character.SetGuildInfo("", packets.GuildMark{})
+		character.Write(packets.GuildLoaded(nil))
+	} else {
+		character.SetGuildInfo(guild.Name(), guild.Mark())
+		data := w.GuildData(guild)
+		character.Write(packets.GuildLoaded(&data))
+	}
+
+	if f := character.Field(); f != nil {
+		f.BroadcastExcept(packets.UserGuildNameChanged(character.ID(), character.GuildName()), character)
+		f.BroadcastExcept(packets.UserGuildMarkChanged(character.ID(), character.GuildMark()), character)
+	}
+}
+
+// BroadcastGuild sends a packet to every online member of a guild
+func (w *World) BroadcastGuild(guild *Guild, p protocol.Packet) {
+	for _, charID := range guild.memberIDs() {
+		if client, ok := w.GetClient(charID); ok {
+			_ = client.Write(p)
+		}
+	}
+}
+
+// AddGuildMember adds a character to a guild at the lowest rank and tells every member
+func (w *World) AddGuildMember(ctx context.Context, guild *Guild, character *field.Character) error {
+	guildID := guild.ID()
+	if err := w.server.repos.Guilds.SetMember(ctx, character.ID(), &guildID, models.GuildGradeLowest); err != nil {
+		return err
+	}
+
+	member := guildMemberOf(character, models.GuildGradeLowest)
+	member.Online = true
+	guild.addMember(member)
+	character.SetGuild(guildID, models.GuildGradeLowest)
+
+	w.BroadcastGuild(guild, packets.GuildJoined(guildID, member))
+	w.ShowGuild(character, guild)
+	return nil
+}
+
+// RemoveGuildMember takes a member out of a guild and tells the remaining members
+// and the member themselves
+func (w *World) RemoveGuildMember(ctx context.Context, guild *Guild, charID uint, name string, expelled bool) error {
+	if err := w.server.repos.Guilds.SetMember(ctx, charID, nil, 0); err != nil {
+		return err
+	}
+	if !guild.removeMember(charID) {
+		return nil
+	}
+
+	p := packets.GuildWithdrawn(guild.ID(), charID, name, expelled)
+	w.BroadcastGuild(guild, p)
+	if client, ok := w.GetClient(charID); ok {
+		_ = client.Write(p)
+		if character := client.Character(); character != nil {
+			character.SetGuild(0, 0)
+			w.ShowGuild(character, nil)
+		}
+	}
+	return nil
+}
+
+// SetGuildMemberGrade changes a member's rank and tells every member
+func (w *World) SetGuildMemberGrade(ctx context.Context, guild *Guild, charID uint, grade byte) error {
+	member, ok := guild.Member(charID)
+	if !ok {
+		return nil
+	}
+	guildID := guild.ID()
+	if err := w.server.repos.Guilds.SetMember(ctx, charID, &guildID, grade); err != nil {
+		return err
+	}
+
+	member.Grade = grade
+	guild.updateMember(member)
+	if client, ok := w.GetClient(charID); ok {
+		if character := client.Character(); character != nil {
+			character.SetGuild(guildID, grade)
+		}
+	}
+
+	w.BroadcastGuild(guild, packets.GuildMemberGradeChanged(guildID, charID, grade))
+	return nil
+}
+
+// SetGuildMark changes the emblem and shows it over the heads of online members
+func (w *World) SetGuildMark(ctx context.Context, guild *Guild, mark packets.GuildMark) error {
+	if err := guild.update(ctx, w, func(model *models.Guild) {
+		model.MarkBg, model.MarkBgColor = mark.Bg, mark.BgColor
+		model.Mark, model.MarkColor = mark.Mark, mark.Color
+	}); err != nil {
+		return err
+	}
+
+	w.BroadcastGuild(guild, packets.GuildMarkChanged(guild.ID(), mark))
+	for _, charID := range guild.memberIDs() {
+		client, ok := w.GetClient(charID)
+		if !ok {
+			continue
+		}
+		if character := client.Character(); character != nil {
+			character.SetGuildInfo(guild.Name(), mark)
+			if f := character.Field(); f != nil {
+				f.BroadcastExcept(packets.UserGuildMarkChanged(charID, mark), character)
+			}
+		}
+	}
+	return nil
+}
+
+// LoginGuildMember loads a logged in character's guild, shows it and tells the
+// other members. Characters whose guild is gone, or who were expelled while
+// offline, are dropped from it.
+func (w *World) LoginGuildMember(ctx context.Context, character *field.Character) {
+	guildID := character.GuildID()
+	if guildID == 0 {
+		return
+	}
+
+	guild, err := w.LoadGuild(ctx, guildID)
+	if err != nil {
+		return
+	}
+	if guild == nil {
+		character.SetGuild(0, 0)
+		return
+	}
+	member, ok := guild.Member(character.ID())
+	if !ok {
+		character.SetGuild(0, 0)
+		return
+	}
+
+	// Level and job may have changed since the guild was cached
+	member.Level, member.Job = character.Level(), character.Job()
+	guild.updateMember(member)
+	character.SetGuild(guildID, member.Grade)
+	character.SetGuildInfo(guild.Name(), guild.Mark())
+
+	w.BroadcastGuild(guild, packets.GuildMemberOnline(guildID, character.ID(), true))
+}
+
+// SetGuildMemberOffline greys out a member who logged off in the guild window
+func (w *World) SetGuildMemberOffline(character *field.Character) {
+	guild, ok := w.GetGuild(character.GuildID())
+	if !ok {
+		return
+	}
+	w.BroadcastGuild(guild, packets.GuildMemberOnline(guild.ID(), character.ID(), false))
+}
+
+// UpdateGuildMember refreshes a member's level and job in the guild window of every member
+func (w *World) UpdateGuildMember(character *field.Character) {
+	guild, ok := w.GetGuild(character.GuildID())
+	if !ok {
+		return
+	}
+	member, ok := guild.Member(character.ID())
+	if !ok {
+		return
+	}
+	member.Level, member.Job = character.Level(), character.Job()
+	guild.updateMember(member)
+
+	w.BroadcastGuild(guild, packets.GuildMemberChanged(guild.ID(), character.ID(), member.Level, member.Job))
+}
+
+// AddGuildInvite records an invite, failing if the character already has one pending
+func (w *World) AddGuildInvite(charID, guildID, inviterID uint) bool {
+	w.guildsMu.Lock()
+	defer w.guildsMu.Unlock()
+	if invite, ok := w.guildInvites[charID]; ok && time.Now().Before(invite.expires) {
+		return false
+	}
+	w.guildInvites[charID] = guildInvite{
+		guildID:   guildID,
+		inviterID: inviterID,
+		expires:   time.Now().Add(guildInviteTimeout),
+	}
+	return true
+}
+
+// TakeGuildInvite consumes a character's pending invite to a guild, returning the inviter
+func (w *World) TakeGuildInvite(charID, guildID uint) (uint, bool) {
+	w.guildsMu.Lock()
+	defer w.guildsMu.Unlock()
+	invite, ok := w.guildInvites[charID]
+	if !ok || invite.guildID != guildID {
+		return 0, false
+	}
+	delete(w.guildInvites, charID)
+	if time.Now().After(invite.expires) {
+		return 0, false
+	}
+	return invite.inviterID, true
+}
+
+// OpenGuildDialog remembers that an NPC opened the guild name or emblem dialog
+func (w *World) OpenGuildDialog(charID uint, result byte) {
+	w.guildsMu.Lock()
+	defer w.guildsMu.Unlock()
+	w.guildDialogs[charID] = guildDialog{
+		result:  result,
+		expires: time.Now().Add(guildDialogTimeout),
+	}
+}
+
+// TakeGuildDialog consumes an open guild dialog of the given kind
+func (w *World) TakeGuildDialog(charID uint, result byte) bool {
+	w.guildsMu.Lock()
+	defer w.guildsMu.Unlock()
+	dialog, ok := w.guildDialogs[charID]
+	if !ok || dialog.result != result {
+		return false
+	}
+	delete(w.guildDialogs, charID)
+	return time.Now().Before(dialog.expires)
+}
@@ -0,0 +1,386 @@
+package server
+
+import (
+	"log"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+const (
+	minGuildNameLength  = 4
+	maxGuildNameLength  = 12
+	maxGuildGradeLength = 12
+	maxGuildNotice      = 100
+)
+
+func (h *ChannelHandler) handleGuildRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil || h.client.server.Repos().Guilds == nil {
+		return
+	}
+
+	switch request := reader.ReadByte(); request {
+	case packets.GuildRequestLoad:
+		h.sendGuild(character)
+	case packets.GuildRequestCheckGuildName:
+		name := reader.ReadString()
+		h.createGuild(character, name)
+	case packets.GuildRequestInvite:
+		name := reader.ReadString()
+		h.inviteGuild(character, name)
+	case packets.GuildRequestJoin:
+		guildID := uint(reader.ReadInt())
+		_ = reader.ReadInt() // character ID
+		h.joinGuild(character, guildID)
+	case packets.GuildRequestWithdraw:
+		_ = reader.ReadInt()    // character ID
+		_ = reader.ReadString() // character name
+		h.withdrawGuild(character)
+	case packets.GuildRequestKick:
+		targetID := uint(reader.ReadInt())
+		targetName := reader.ReadString()
+		h.kickGuild(character, targetID, targetName)
+	case packets.GuildRequestSetGradeName:
+		var names [5]string
+		for i := range names {
+			names[i] = reader.ReadString()
+		}
+		h.setGuildGradeNames(character, names)
+	case packets.GuildRequestSetMemberGrade:
+		targetID := uint(reader.ReadInt())
+		grade := reader.ReadByte()
+		h.setGuildMemberGrade(character, targetID, grade)
+	case packets.GuildRequestSetMark:
+		mark := packets.GuildMark{
+			Bg:      int16(reader.ReadShort()),
+			BgColor: reader.ReadByte(),
+			Mark:    int16(reader.ReadShort()),
+			Color:   reader.ReadByte(),
+		}
+		h.setGuildMark(character, mark)
+	case packets.GuildRequestSetNotice:
+		notice := reader.ReadString()
+		h.setGuildNotice(character, notice)
+	default:
+		log.Printf("[Guild] Unhandled guild request %d from %s", request, character.Name())
+	}
+}
+
+// handleGuildResult handles the invited character's answer to a guild invite
+func (h *ChannelHandler) handleGuildResult(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	result := reader.ReadByte()
+	switch result {
+	case packets.GuildResultInviteRejected, packets.GuildResultInviteBlockedUser, packets.GuildResultInviteAlreadyInvited:
+		inviterName := reader.ReadString()
+		world := h.client.Channel().World()
+		ref, ok := world.FindCharacterByName(inviterName)
+		if !ok {
+			return
+		}
+		inviter, ok := world.GetClient(ref.CharacterID)
+		if !ok || inviter.Character() == nil {
+			return
+		}
+		if _, ok := world.TakeGuildInvite(character.ID(), inviter.Character().GuildID()); ok {
+			_ = inviter.Write(packets.GuildMessageName(result, character.Name()))
+		}
+	default:
+		log.Printf("[Guild] Unhandled guild result %d from %s", result, character.Name())
+	}
+}
+
+// currentGuild returns the character's cached guild and their entry in it
+func (h *ChannelHandler) currentGuild(character *field.Character) (*Guild, packets.GuildMember, bool) {
+	guild, ok := h.client.Channel().World().GetGuild(character.GuildID())
+	if !ok {
+		return nil, packets.GuildMember{}, false
+	}
+	member, ok := guild.Member(character.ID())
+	return guild, member, ok
+}
+
+// sendGuild sends the character their guild window
+func (h *ChannelHandler) sendGuild(character *field.Character) {
+	world := h.client.Channel().World()
+	guild, _, ok := h.currentGuild(character)
+	if !ok {
+		h.client.Write(packets.GuildLoaded(nil))
+		return
+	}
+	data := world.GuildData(guild)
+	h.client.Write(packets.GuildLoaded(&data))
+}
+
+// createGuild creates a guild with the name entered in the dialog an NPC opened
+func (h *ChannelHandler) createGuild(character *field.Character, name string) {
+	server := h.client.server
+	ctx := server.Context()
+	world := h.client.Channel().World()
+
+	if !world.TakeGuildDialog(character.ID(), packets.GuildResultInputGuildName) {
+		log.Printf("[Guild] %s sent a guild name without talking to an NPC", character.Name())
+		return
+	}
+	if character.GuildID() != 0 {
+		h.client.Write(packets.GuildMessage(packets.GuildResultCreateAlreadyJoined))
+		return
+	}
+	if game.Job(character.Job()).IsBeginner() {
+		h.client.Write(packets.GuildMessage(packets.GuildResultCreateBeginner))
+		return
+	}
+	if character.Mesos() < guildCreateCost {
+		h.client.Write(packets.GuildMessage(packets.GuildResultCreateUnknown))
+		return
+	}
+	if len(name) < minGuildNameLength || len(name) > maxGuildNameLength || !isAlphanumeric(name) {
+		world.OpenGuildDialog(character.ID(), packets.GuildResultInputGuildName)
+		h.client.Write(packets.GuildMessage(packets.GuildResultCheckGuildNameAlreadyUsed))
+		return
+	}
+
+	exists, err := server.Repos().Guilds.NameExists(ctx, world.ID(), name)
+	if err != nil {
+		log.Printf("[Guild] Failed to check guild name %q: %v", name, err)
+		h.client.Write(packets.GuildMessage(packets.GuildResultCreateUnknown))
+		return
+	}
+	if exists {
+		world.OpenGuildDialog(character.ID(), packets.GuildResultInputGuildName)
+		h.client.Write(packets.GuildMessage(packets.GuildResultCheckGuildNameAlreadyUsed))
+		return
+	}
+
+	// The fee is taken before the guild is saved and refunded if that fails
+	character.GainMesos(-guildCreateCost)
+
+	guild, err := world.CreateGuild(ctx, character, name)
+	if err != nil {
+		log.Printf("[Guild] Failed to create guild %q for %s: %v", name, character.Name(), err)
+		character.GainMesos(guildCreateCost)
+		h.client.Write(packets.GuildMessage(packets.GuildResultCreateUnknown))
+		return
+	}
+
+	log.Printf("[Guild] %s created guild %q (%d)", character.Name(), name, guild.ID())
+}
+
+// inviteGuild invites an online character to the inviter's guild
+func (h *ChannelHandler) inviteGuild(character *field.Character, targetName string) {
+	world := h.client.Channel().World()
+	guild, member, ok := h.currentGuild(character)
+	if !ok || member.Grade > models.GuildGradeJrMaster {
+		return
+	}
+	if guild.IsFull() {
+		h.client.Write(packets.GuildMessage(packets.GuildResultJoinAlreadyFull))
+		return
+	}
+
+	ref, ok := world.FindCharacterByName(targetName)
+	if !ok {
+		h.client.Write(packets.GuildMessage(packets.GuildResultJoinUnknownUser))
+		return
+	}
+	target, ok := world.GetClient(ref.CharacterID)
+	if !ok || target.Character() == nil {
+		h.client.Write(packets.GuildMessage(packets.GuildResultJoinUnknownUser))
+		return
+	}
+	if target.Character().GuildID() != 0 {
+		h.client.Write(packets.GuildMessage(packets.GuildResultJoinAlreadyJoined))
+		return
+	}
+	if !world.AddGuildInvite(ref.CharacterID, guild.ID(), character.ID()) {
+		h.client.Write(packets.GuildMessageName(packets.GuildResultInviteAlreadyInvited, ref.CharacterName))
+		return
+	}
+
+	_ = target.Write(packets.GuildInvite(guild.ID(), character.Name(), int32(character.Level()), int32(character.Job())))
+}
+
+// joinGuild adds the character to a guild they were invited to
+func (h *ChannelHandler) joinGuild(character *field.Character, guildID uint) {
+	world := h.client.Channel().World()
+
+	if _, ok := world.TakeGuildInvite(character.ID(), guildID); !ok {
+		log.Printf("[Guild] %s tried to join guild %d without an invite", character.Name(), guildID)
+		h.client.Write(packets.GuildMessage(packets.GuildResultJoinUnknown))
+		return
+	}
+	if character.GuildID() != 0 {
+		h.client.Write(packets.GuildMessage(packets.GuildResultJoinAlreadyJoined))
+		return
+	}
+	guild, ok := world.GetGuild(guildID)
+	if !ok {
+		h.client.Write(packets.GuildMessage(packets.GuildResultJoinUnknown))
+		return
+	}
+	if guild.IsFull() {
+		h.client.Write(packets.GuildMessage(packets.GuildResultJoinAlreadyFull))
+		return
+	}
+
+	if err := world.AddGuildMember(h.client.server.Context(), guild, character); err != nil {
+		log.Printf("[Guild] Failed to add %s to guild %d: %v", character.Name(), guildID, err)
+		h.client.Write(packets.GuildMessage(packets.GuildResultJoinUnknown))
+		return
+	}
+	log.Printf("[Guild] %s joined guild %d", character.Name(), guildID)
+}
+
+// withdrawGuild leaves the character's guild. The master has to disband it instead.
+func (h *ChannelHandler) withdrawGuild(character *field.Character) {
+	world := h.client.Channel().World()
+	guild, member, ok := h.currentGuild(character)
+	if !ok {
+		h.client.Write(packets.GuildMessage(packets.GuildResultWithdrawNotJoined))
+		return
+	}
+	if member.Grade == models.GuildGradeMaster {
+		h.client.Write(packets.GuildMessage(packets.GuildResultWithdrawUnknown))
+		return
+	}
+
+	if err := world.RemoveGuildMember(h.client.server.Context(), guild, character.ID(), character.Name(), false); err != nil {
+		log.Printf("[Guild] Failed to remove %s from guild %d: %v", character.Name(), guild.ID(), err)
+		h.client.Write(packets.GuildMessage(packets.GuildResultWithdrawUnknown))
+		return
+	}
+	log.Printf("[Guild] %s left guild %d", character.Name(), guild.ID())
+}
+
+// kickGuild expels a lower ranked member
+func (h *ChannelHandler) kickGuild(character *field.Character, targetID uint, targetName string) {
+	world := h.client.Channel().World()
+	guild, member, ok := h.currentGuild(character)
+	if !ok || member.Grade > models.GuildGradeJrMaster {
+		return
+	}
+	target, ok := guild.Member(targetID)
+	if !ok {
+		h.client.Write(packets.GuildMessage(packets.GuildResultKickNotJoined))
+		return
+	}
+	if target.Grade <= member.Grade {
+		h.client.Write(packets.GuildMessage(packets.GuildResultKickUnknown))
+		return
+	}
+
+	if err := world.RemoveGuildMember(h.client.server.Context(), guild, targetID, target.Name, true); err != nil {
+		log.Printf("[Guild] Failed to expel %s from guild %d: %v", targetName, guild.ID(), err)
+		h.client.Write(packets.GuildMessage(packets.GuildResultKickUnknown))
+		return
+	}
+	log.Printf("[Guild] %s expelled %s from guild %d", character.Name(), target.Name, guild.ID())
+}
+
+// setGuildGradeNames renames the ranks. Only the master may do this.
+func (h *ChannelHandler) setGuildGradeNames(character *field.Character, names [5]string) {
+	world := h.client.Channel().World()
+	guild, member, ok := h.currentGuild(character)
+	if !ok || member.Grade != models.GuildGradeMaster {
+		return
+	}
+	for _, name := range names {
+		if len(name) > maxGuildGradeLength {
+			h.client.Write(packets.GuildMessage(packets.GuildResultSetGradeNameUnknown))
+			return
+		}
+	}
+	for _, name := range names[:models.GuildGradeMember] {
+		if name == "" {
+			h.client.Write(packets.GuildMessage(packets.GuildResultSetGradeNameUnknown))
+			return
+		}
+	}
+
+	if err := guild.update(h.client.server.Context(), world, func(model *models.Guild) {
+		model.SetGradeNames(names)
+	}); err != nil {
+		log.Printf("[Guild] Failed to rename ranks of guild %d: %v", guild.ID(), err)
+		h.client.Write(packets.GuildMessage(packets.GuildResultSetGradeNameUnknown))
+		return
+	}
+	world.BroadcastGuild(guild, packets.GuildGradeNamesChanged(guild.ID(), names))
+}
+
+// setGuildMemberGrade changes a lower ranked member's rank. The master can appoint
+// jr. masters; jr. masters can only move members between the member ranks.
+func (h *ChannelHandler) setGuildMemberGrade(character *field.Character, targetID uint, grade byte) {
+	world := h.client.Channel().World()
+	guild, member, ok := h.currentGuild(character)
+	if !ok || member.Grade > models.GuildGradeJrMaster {
+		return
+	}
+	target, ok := guild.Member(targetID)
+	if !ok || target.Grade <= member.Grade || grade <= member.Grade || grade > models.GuildGradeLowest {
+		h.client.Write(packets.GuildMessage(packets.GuildResultSetMemberGradeUnknown))
+		return
+	}
+
+	if err := world.SetGuildMemberGrade(h.client.server.Context(), guild, targetID, grade); err != nil {
+		log.Printf("[Guild] Failed to change rank of %s in guild %d: %v", target.Name, guild.ID(), err)
+		h.client.Write(packets.GuildMessage(packets.GuildResultSetMemberGradeUnknown))
+	}
+}
+
+// setGuildMark changes the emblem chosen in the dialog an NPC opened
+func (h *ChannelHandler) setGuildMark(character *field.Character, mark packets.GuildMark) {
+	world := h.client.Channel().World()
+	if !world.TakeGuildDialog(character.ID(), packets.GuildResultInputMark) {
+		log.Printf("[Guild] %s sent a guild emblem without talking to an NPC", character.Name())
+		return
+	}
+	guild, member, ok := h.currentGuild(character)
+	if !ok || member.Grade != models.GuildGradeMaster {
+		return
+	}
+	if character.Mesos() < guildMarkCost {
+		h.client.Write(packets.GuildMessage(packets.GuildResultSetMarkUnknown))
+		return
+	}
+
+	if err := world.SetGuildMark(h.client.server.Context(), guild, mark); err != nil {
+		log.Printf("[Guild] Failed to change emblem of guild %d: %v", guild.ID(), err)
+		h.client.Write(packets.GuildMessage(packets.GuildResultSetMarkUnknown))
+		return
+	}
+	character.GainMesos(-guildMarkCost)
+}
+
+// setGuildNotice changes the notice. The master and jr. masters may do this.
+func (h *ChannelHandler) setGuildNotice(character *field.Character, notice string) {
+	world := h.client.Channel().World()
+	guild, member, ok := h.currentGuild(character)
+	if !ok || member.Grade > models.GuildGradeJrMaster || len(notice) > maxGuildNotice {
+		return
+	}
+
+	if err := guild.update(h.client.server.Context(), world, func(model *models.Guild) {
+		model.Notice = notice
+	}); err != nil {
+		log.Printf("[Guild] Failed to change notice of guild %d: %v", guild.ID(), err)
+		return
+	}
+	world.BroadcastGuild(guild, packets.GuildNoticeChanged(guild.ID(), notice))
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"log"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+func (h *ChannelHandler) handleUserChangeSlotPosition(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	_ = reader.ReadInt() // update time
+	invType := packets.InventoryTypeFromClient(reader.ReadByte())
+	from := int16(reader.ReadShort())
+	to := int16(reader.ReadShort())
+	count := int16(reader.ReadShort())
+
+	if to == 0 {
+		h.dropInventoryItem(character, invType, from, count)
+		return
+	}
+
+	if err := character.ChangeSlot(invType, from, to, count); err != nil {
+		log.Printf("[Inventory] %s: change slot %d -> %d failed: %v", character.Name(), from, to, err)
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	// Equipment changes are visible to everyone on the map
+	if from < 0 || to < 0 {
+		if currentField := character.Field(); currentField != nil {
+			currentField.BroadcastExcept(UserAvatarModified(character), character)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"log"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers/item"
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+// returnToNearestTown is the moveTo value of return scrolls that send the
+// character to the current map's return map
+const returnToNearestTown = 999999999
+
+func (h *ChannelHandler) handleUserStatChangeItemUseRequest(reader *protocol.Reader) {
+	_ = reader.ReadInt() // update time
+	slot := int16(reader.ReadShort())
+	itemID := reader.ReadInt()
+
+	h.useConsumeItem(slot, itemID)
+}
+
+func (h *ChannelHandler) handleUserPortalScrollUseRequest(reader *protocol.Reader) {
+	_ = reader.ReadInt() // update time
+	slot := int16(reader.ReadShort())
+	itemID := reader.ReadInt()
+
+	h.useConsumeItem(slot, itemID)
+}
+
+func (h *ChannelHandler) handleUserStatChangeItemCancelRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	// Item buffs are keyed by the negated item ID
+	reason := reader.ReadInt()
+	if reason > 0 {
+		reason = -reason
+	}
+	character.CancelTemporaryStats(reason)
+}
+
+// useConsumeItem uses one consumable from a slot: heals, cures, buffs and
+// moveTo warps are applied from the item's spec
+func (h *ChannelHandler) useConsumeItem(slot int16, itemID int32) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	currentField := character.Field()
+	it := character.ItemAt(models.InvConsume, slot)
+	if currentField == nil || it == nil || it.ItemID != itemID || character.HP() <= 0 {
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	var info *item.ItemInfo
+	if provider := h.client.server.ItemProvider(); provider != nil {
+		info = provider.GetItemInfo(itemID)
+	}
+	if info == nil {
+		log.Printf("[Item] %s used item %d with no item info", character.Name(), itemID)
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	// Resolve the destination before using the item so a bad one doesn't waste it
+	var targetField *field.Field
+	if moveTo := info.GetSpecOr(item.KeySpecMoveTo, 0); moveTo != 0 {
+		if moveTo == returnToNearestTown {
+			moveTo = currentField.ReturnMap()
+		}
+		if moveTo == returnToNearestTown || moveTo == currentField.ID() {
+			h.client.Write(packets.EnableActions())
+			return
+		}
+
+		var err error
+		targetField, err = h.client.channel.GetField(moveTo)
+		if err != nil {
+			log.Printf("[Item] %s: item %d has bad moveTo field %d: %v", character.Name(), itemID, moveTo, err)
+			h.client.Write(packets.EnableActions())
+			return
+		}
+	}
+
+	if _, err := character.TakeItem(models.InvConsume, slot, 1); err != nil {
+		log.Printf("[Item] %s: failed to use item %d: %v", character.Name(), itemID, err)
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	h.client.Write(packets.StatChanged(true, character.ApplyConsumeItem(info)))
+
+	if targetField != nil {
+		character.TransferToField(targetField, "")
+		if err := h.client.Write(SetField(character, int(h.client.channel.ID()))); err != nil {
+			log.Printf("[Item] Failed to send SetField after return scroll: %v", err)
+		}
+		h.sendFieldEntities(character, targetField)
+		h.client.Write(packets.EnableActions())
+	}
+}
+
+func (h *ChannelHandler) handleUserUpgradeItemUseRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	_ = reader.ReadInt() // update time
+	scrollSlot := int16(reader.ReadShort())
+	equipSlot := int16(reader.ReadShort())
+	whiteScroll := reader.ReadShort()&2 != 0
+	_ = reader.ReadBool() // bEnchantSkill
+
+	result, err := character.UpgradeEquip(scrollSlot, equipSlot, whiteScroll)
+	if err != nil {
+		log.Printf("[Item] %s: scroll %d on equip %d failed: %v", character.Name(), scrollSlot, equipSlot, err)
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	currentField := character.Field()
+	if currentField == nil {
+		return
+	}
+	currentField.Broadcast(packets.UserItemUpgradeEffect(character.ID(), result.Success, result.Destroyed, result.WhiteScroll))
+	if result.Equipped {
+		currentField.BroadcastExcept(UserAvatarModified(character), character)
+	}
+}
+
+func (h *ChannelHandler) handleUserItemReleaseRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	_ = reader.ReadInt() // update time
+	glassSlot := int16(reader.ReadShort())
+	equipSlot := int16(reader.ReadShort())
+
+	if err := character.RevealPotential(glassSlot, equipSlot); err != nil {
+		log.Printf("[Item] %s: magnifying glass %d on equip %d failed: %v", character.Name(), glassSlot, equipSlot, err)
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	if currentField := character.Field(); currentField != nil {
+		currentField.Broadcast(packets.UserItemReleaseEffect(character.ID(), equipSlot))
+	}
+}
+
+func (h *ChannelHandler) handleUserConsumeCashItemUseRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	_ = reader.ReadInt() // update time
+	slot := int16(reader.ReadShort())
+	itemID := reader.ReadInt()
+
+	it := character.ItemAt(models.InvCash, slot)
+	if it == nil || it.ItemID != itemID {
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	switch itemID {
+	case field.MiracleCubeItemID:
+		equipSlot := int16(reader.ReadInt())
+		if _, err := character.CubePotential(slot, equipSlot); err != nil {
+			log.Printf("[Item] %s: miracle cube on equip %d failed: %v", character.Name(), equipSlot, err)
+			h.client.Write(packets.EnableActions())
+			return
+		}
+		if currentField := character.Field(); currentField != nil {
+			currentField.Broadcast(packets.UserItemUnreleaseEffect(character.ID(), true))
+		}
+	case field.APResetItemID:
+		to := reader.ReadInt()
+		from := reader.ReadInt()
+		changed, err := character.ResetAP(slot, to, from)
+		if err != nil {
+			log.Printf("[Item] %s: AP reset from 0x%x to 0x%x failed: %v", character.Name(), from, to, err)
+			h.client.Write(packets.EnableActions())
+			return
+		}
+		h.client.Write(packets.StatChanged(true, changed))
+	default:
+		log.Printf("[Item] %s used unhandled cash item %d", character.Name(), itemID)
+		h.client.Write(packets.EnableActions())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"log"
+	"time"
 
 	"github.com/Jinw00Arise/Jinwoo/internal/data/repositories"
 	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
@@ -12,6 +13,12 @@ import (
 	"github.com/Jinw00Arise/Jinwoo/internal/utils"
 )
 
+// PIC length limits enforced by the client
+const (
+	minPICLength = 6
+	maxPICLength = 16
+)
+
 // LoginHandler handles login-specific packet processing
 type LoginHandler struct {
 	client *Client
@@ -50,6 +57,12 @@ func (h *LoginHandler) Handle(p protocol.Packet) {
 		h.handleCreateNewCharacter(reader)
 	case RecvSelectCharacter:
 		h.handleSelectCharacter(reader)
+	case RecvEnableSPWRequest:
+		h.handleEnableSPWRequest(reader)
+	case RecvCheckSPWRequest:
+		h.handleCheckSPWRequest(reader)
+	case RecvDeleteCharacter:
+		h.handleDeleteCharacter(reader)
 	case RecvUpdateScreenSetting:
 		h.handleUpdateScreenSetting(reader)
 	case RecvCreateSecurityHandle, RecvClientDumpLog:
@@ -123,6 +136,12 @@ func (h *LoginHandler) handleCheckPassword(reader *protocol.Reader) {
 		return
 	}
 
+	if picLocked(account) {
+		log.Printf("[Login] Account %s is locked out after failed PIC attempts", username)
+		_ = h.client.Write(CheckPasswordResultFailed(LoginResultTemporaryBlocked))
+		return
+	}
+
 	// Check if already online
 	if server.IsAccountOnline(account.ID) {
 		log.Printf("[Login] Account %s already connected", username)
@@ -215,7 +234,7 @@ func (h *LoginHandler) handleSelectWorld(reader *protocol.Reader) {
 		return
 	}
 
-	if err := h.client.Write(SelectWorldResultSuccess(characters, equipsByChar, h.characterSlots)); err != nil {
+	if err := h.client.Write(SelectWorldResultSuccess(characters, equipsByChar, h.loginOpt(), h.characterSlots)); err != nil {
 		log.Printf("[Login] Failed to send char list: %v", err)
 		return
 	}
@@ -413,6 +432,76 @@ func (h *LoginHandler) handleSelectCharacter(reader *protocol.Reader) {
 	_ = reader.ReadString() // macAddress
 	_ = reader.ReadString() // macAddressWithHDDSerial
 
+	if h.client.server.Config().PICEnabled {
+		log.Printf("[Login] SECURITY: Account %d tried to select character %d without a PIC",
+			h.client.AccountID(), characterID)
+		_ = h.client.Close()
+		return
+	}
+
+	h.selectCharacter(characterID)
+}
+
+func (h *LoginHandler) handleEnableSPWRequest(reader *protocol.Reader) {
+	_ = reader.ReadBool() // bUnknown
+	characterID := reader.ReadInt()
+	_ = reader.ReadString() // macAddress
+	_ = reader.ReadString() // macAddressWithHDDSerial
+	pic := reader.ReadString()
+
+	server := h.client.server
+	account := h.client.Account()
+	if account == nil {
+		log.Printf("[Login] SECURITY: PIC registration sent before login")
+		_ = h.client.Close()
+		return
+	}
+
+	if !server.Config().PICEnabled || account.PICHash != "" {
+		log.Printf("[Login] SECURITY: Account %d tried to register a PIC it can't set", account.ID)
+		_ = h.client.Close()
+		return
+	}
+
+	// The client enforces the same length when the PIC is typed
+	if len(pic) < minPICLength || len(pic) > maxPICLength {
+		log.Printf("[Login] Account %d sent an invalid PIC length: %d", account.ID, len(pic))
+		_ = h.client.Write(SelectCharacterResultFailed(LoginResultUnknown))
+		return
+	}
+
+	if err := server.Repos().Accounts.SetPIC(server.Context(), account, pic); err != nil {
+		log.Printf("[Login] Failed to set PIC for account %d: %v", account.ID, err)
+		_ = h.client.Write(SelectCharacterResultFailed(LoginResultSystemError))
+		return
+	}
+
+	log.Printf("[Login] Account %d registered a PIC", account.ID)
+	h.selectCharacter(characterID)
+}
+
+func (h *LoginHandler) handleCheckSPWRequest(reader *protocol.Reader) {
+	pic := reader.ReadString()
+	characterID := reader.ReadInt()
+	_ = reader.ReadString() // macAddress
+	_ = reader.ReadString() // macAddressWithHDDSerial
+
+	if !h.client.server.Config().PICEnabled {
+		log.Printf("[Login] SECURITY: Account %d sent a PIC with PIC disabled", h.client.AccountID())
+		_ = h.client.Close()
+		return
+	}
+
+	if !h.checkPIC(pic) {
+		_ = h.client.Write(CheckSPWResult())
+		return
+	}
+
+	h.selectCharacter(characterID)
+}
+
+// selectCharacter validates the chosen character and migrates the client to its channel
+func (h *LoginHandler) selectCharacter(characterID int32) {
 	server := h.client.server
 	ctx := server.Context()
 	accountID := h.client.AccountID()
@@ -461,3 +550,150 @@ func (h *LoginHandler) handleSelectCharacter(reader *protocol.Reader) {
 		return
 	}
 }
+
+func (h *LoginHandler) handleDeleteCharacter(reader *protocol.Reader) {
+	secondaryPassword := reader.ReadString()
+	characterID := reader.ReadInt()
+
+	server := h.client.server
+	ctx := server.Context()
+	account := h.client.Account()
+	if account == nil {
+		log.Printf("[Login] SECURITY: Character %d delete requested before login", characterID)
+		_ = h.client.Close()
+		return
+	}
+
+	// Validate character ownership
+	char, err := server.Repos().Characters.FindByID(ctx, uint(characterID))
+	if err != nil {
+		log.Printf("[Login] Character %d not found: %v", characterID, err)
+		_ = h.client.Write(DeleteCharacterResult(characterID, LoginResultSystemError))
+		return
+	}
+	if char.AccountID != account.ID {
+		log.Printf("[Login] SECURITY: Account %d attempted to delete character %d owned by account %d",
+			account.ID, characterID, char.AccountID)
+		_ = h.client.Close()
+		return
+	}
+
+	if server.Config().PICEnabled && account.PICHash != "" {
+		if !h.checkPIC(secondaryPassword) {
+			_ = h.client.Write(DeleteCharacterResult(characterID, LoginResultIncorrectSPW))
+			return
+		}
+	} else if !checkBirthday(account, secondaryPassword) {
+		log.Printf("[Login] Account %d entered the wrong birthday deleting character %d", account.ID, characterID)
+		_ = h.client.Write(DeleteCharacterResult(characterID, LoginResultInvalidBirthDate))
+		return
+	}
+
+	if server.IsCharacterOnline(char.ID) {
+		log.Printf("[Login] Character %d is online and can't be deleted", characterID)
+		_ = h.client.Write(DeleteCharacterResult(characterID, LoginResultAlreadyConnected))
+		return
+	}
+
+	if char.GuildID != nil && char.GuildGrade == models.GuildGradeMaster {
+		_ = h.client.Write(DeleteCharacterResult(characterID, LoginResultDeleteGuildMaster))
+		return
+	}
+
+	if err := server.Repos().Characters.Delete(ctx, char.ID); err != nil {
+		log.Printf("[Login] Failed to delete character %d: %v", characterID, err)
+		_ = h.client.Write(DeleteCharacterResult(characterID, LoginResultSystemError))
+		return
+	}
+
+	// Drop the character from their guild's member list if it's loaded
+	if char.GuildID != nil {
+		if world, ok := server.GetWorld(char.WorldID); ok {
+			if guild, ok := world.GetGuild(*char.GuildID); ok {
+				_ = world.RemoveGuildMember(ctx, guild, char.ID, char.Name, false)
+			}
+		}
+	}
+
+	log.Printf("[Login] Deleted character %s (%d)", char.Name, characterID)
+	if err := h.client.Write(DeleteCharacterResult(characterID, LoginResultSuccess)); err != nil {
+		log.Printf("[Login] Failed to send delete character result: %v", err)
+	}
+}
+
+// checkBirthday compares the code typed into the delete prompt against the account's
+// birthday (YYYYMMDD). Accounts without a birthday on record never match.
+func checkBirthday(account *models.Account, code string) bool {
+	if account.Birthday == nil {
+		return false
+	}
+	return code == account.Birthday.Format("20060102")
+}
+
+// loginOpt returns the PIC mode the client should use when selecting a character
+func (h *LoginHandler) loginOpt() byte {
+	if !h.client.server.Config().PICEnabled {
+		return LoginOptNoSPW
+	}
+	account := h.client.Account()
+	if account == nil {
+		return LoginOptNoSPW
+	}
+	if account.PICHash == "" {
+		return LoginOptRegisterSPW
+	}
+	return LoginOptCheckSPW
+}
+
+// checkPIC verifies the account's PIC, counting failures. Once the configured number
+// of attempts is used up the account is locked and the client disconnected.
+func (h *LoginHandler) checkPIC(pic string) bool {
+	server := h.client.server
+	cfg := server.Config()
+	accounts := server.Repos().Accounts
+	account := h.client.Account()
+	if account == nil {
+		log.Printf("[Login] SECURITY: PIC sent before login")
+		_ = h.client.Close()
+		return false
+	}
+
+	if picLocked(account) {
+		_ = h.client.Close()
+		return false
+	}
+
+	if accounts.VerifyPIC(account, pic) {
+		if account.PICFailures > 0 {
+			account.PICFailures = 0
+			if err := accounts.UpdatePIC(server.Context(), account); err != nil {
+				log.Printf("[Login] Failed to reset PIC failures for account %d: %v", account.ID, err)
+			}
+		}
+		return true
+	}
+
+	account.PICFailures++
+	log.Printf("[Login] Account %d entered an incorrect PIC (%d/%d)", account.ID, account.PICFailures, cfg.PICMaxAttempts)
+
+	locked := account.PICFailures >= cfg.PICMaxAttempts
+	if locked {
+		until := time.Now().Add(cfg.PICLockout)
+		account.PICLockedUntil = &until
+		account.PICFailures = 0
+		log.Printf("[Login] Account %d locked until %s", account.ID, until.Format(time.RFC3339))
+	}
+
+	if err := accounts.UpdatePIC(server.Context(), account); err != nil {
+		log.Printf("[Login] Failed to record PIC failure for account %d: %v", account.ID, err)
+	}
+	if locked {
+		_ = h.client.Close()
+	}
+	return false
+}
+
+// picLocked reports whether the account is still locked out from failed PIC attempts
+func picLocked(account *models.Account) bool {
+	return account.PICLockedUntil != nil && time.Now().Before(*account.PICLockedUntil)
+}
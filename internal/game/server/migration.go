@@ -5,11 +5,15 @@ import (
 	"time"
 
 	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
 )
 
 const (
 	// MigrationTimeout is how long a migration record is valid
 	MigrationTimeout = 30 * time.Second
+
+	// maxPendingPackets caps how many packets are held for a character between channels
+	maxPendingPackets = 50
 )
 
 // MigrateInUser represents a pending migration from login->channel or channel->channel
@@ -29,9 +33,16 @@ func (m *MigrateInUser) IsExpired() bool {
 	return time.Now().After(m.ExpiresAt)
 }
 
+// pendingPackets are packets held for a character between channels
+type pendingPackets struct {
+	packets []protocol.Packet
+	expires time.Time
+}
+
 // MigrationManager handles pending migrations between server components
 type MigrationManager struct {
-	migrations map[uint]*MigrateInUser // charID -> migration
+	migrations map[uint]*MigrateInUser  // charID -> migration
+	pending    map[uint]*pendingPackets // charID -> packets held until arrival
 	mu         sync.RWMutex
 }
 
@@ -39,6 +50,7 @@ type MigrationManager struct {
 func NewMigrationManager() *MigrationManager {
 	mm := &MigrationManager{
 		migrations: make(map[uint]*MigrateInUser),
+		pending:    make(map[uint]*pendingPackets),
 	}
 	go mm.cleanupLoop()
 	return mm
@@ -65,7 +77,9 @@ func (m *MigrationManager) Create(charID, accountID uint, account *models.Accoun
 }
 
 // Consume retrieves and removes a migration record if valid
-// Returns the migration and true if found and not expired, nil and false otherwise
+// Returns the migration and true if found and not expired, nil and false otherwise.
+// A consumed character is arriving: packets keep being held for them until
+// TakePending is called.
 func (m *MigrationManager) Consume(charID uint) (*MigrateInUser, bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -78,9 +92,17 @@ func (m *MigrationManager) Consume(charID uint) (*MigrateInUser, bool) {
 	delete(m.migrations, charID)
 
 	if migration.IsExpired() {
+		delete(m.pending, charID)
 		return nil, false
 	}
 
+	pending, ok := m.pending[charID]
+	if !ok {
+		pending = &pendingPackets{}
+		m.pending[charID] = pending
+	}
+	pending.expires = time.Now().Add(MigrationTimeout)
+
 	return migration, true
 }
 
@@ -96,14 +118,52 @@ func (m *MigrationManager) Exists(charID uint) bool {
 	return !migration.IsExpired()
 }
 
-// Cancel removes a pending migration
+// Cancel removes a pending migration and any packets held for it
 func (m *MigrationManager) Cancel(charID uint) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	delete(m.migrations, charID)
+	delete(m.pending, charID)
 }
 
-// cleanupLoop periodically removes expired migrations
+// Hold queues a packet for a character that is migrating or arriving. If force
+// is set the packet is held even without a migration record.
+// Returns false if the packet was not held.
+func (m *MigrationManager) Hold(charID uint, p protocol.Packet, force bool) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending, ok := m.pending[charID]
+	if !ok {
+		migration, exists := m.migrations[charID]
+		if !force && (!exists || migration.IsExpired()) {
+			return false
+		}
+		pending = &pendingPackets{expires: time.Now().Add(MigrationTimeout)}
+		m.pending[charID] = pending
+	}
+	if len(pending.packets) < maxPendingPackets {
+		pending.packets = append(pending.packets, p)
+	}
+	return true
+}
+
+// TakePending returns and clears the packets held for a character, ending the
+// hold started by Consume
+func (m *MigrationManager) TakePending(charID uint) []protocol.Packet {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending, ok := m.pending[charID]
+	if !ok {
+		return nil
+	}
+	delete(m.pending, charID)
+	return pending.packets
+}
+
+// cleanupLoop periodically removes expired migrations and packets held for
+// characters who never arrived
 func (m *MigrationManager) cleanupLoop() {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
@@ -115,6 +175,12 @@ func (m *MigrationManager) cleanupLoop() {
 				delete(m.migrations, charID)
 			}
 		}
+		now := time.Now()
+		for charID, pending := range m.pending {
+			if now.After(pending.expires) {
+				delete(m.pending, charID)
+			}
+		}
 		m.mu.Unlock()
 	}
 }
@@ -0,0 +1,172 @@
+package server
+
+import (
+	"log"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+func (h *ChannelHandler) handleMobMove(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	currentField := character.Field()
+	if currentField == nil {
+		return
+	}
+
+	move := field.DecodeMobMove(reader)
+	mob := currentField.GetMob(move.ObjectID)
+	if mob == nil || mob.IsDead() {
+		return
+	}
+
+	// Only the controller drives the mob; stale packets from a previous controller are dropped
+	if mob.Controller() != character {
+		return
+	}
+
+	move.MovePath.ApplyTo(mob)
+
+	character.Write(packets.MobCtrlAck(mob.ObjectID(), move.CtrlSN, move.NextAttackPossible(), mob.MP(), 0, 0))
+	currentField.BroadcastExcept(MobMove(move), character)
+}
+
+const (
+	// partyEXPLevelRange is how far below the mob's level a party member who didn't
+	// attack can be and still share the party's EXP
+	partyEXPLevelRange = 5
+
+	// partyEXPBonusRate is the extra EXP for each party member sharing it beyond the first
+	partyEXPBonusRate = 0.05
+)
+
+// killMob removes a dead mob from the field and rewards the characters that damaged it.
+// Other characters are rewarded on their own client goroutines.
+func (c *Channel) killMob(killer *Client, f *field.Field, mob *field.Mob) {
+	f.RemoveMob(mob.ObjectID())
+	f.Broadcast(packets.MobLeaveField(mob.ObjectID(), 1))
+
+	attackers := mob.Attackers()
+	var totalDamage int64
+	for _, dmg := range attackers {
+		totalDamage += dmg
+	}
+	if totalDamage <= 0 {
+		return
+	}
+
+	if owner := topAttacker(f, attackers); owner != nil {
+		c.spawnMobDrops(f, mob, owner)
+	}
+
+	// EXP is split by share of damage dealt. Party members pool their shares.
+	baseEXP := float64(mob.EXP()) * c.Server().Config().ExpRate
+	shares := make(map[uint]float64)
+	partyShares := make(map[uint]float64)
+	for charID, dmg := range attackers {
+		char := f.GetCharacter(charID)
+		if char == nil {
+			continue // left the field before the mob died
+		}
+
+		exp := baseEXP * float64(dmg) / float64(totalDamage)
+		if partyID := char.PartyID(); partyID != 0 {
+			partyShares[partyID] += exp
+		} else {
+			shares[charID] += exp
+		}
+
+		mobID := mob.TemplateID()
+		c.runAs(killer, charID, func(char *field.Character) {
+			updateMobKillQuests(char, mobID)
+		})
+	}
+
+	for partyID, exp := range partyShares {
+		sharePartyEXP(f, mob, partyID, exp, attackers, shares)
+	}
+
+	for charID, share := range shares {
+		exp := int32(share)
+		if exp <= 0 {
+			continue
+		}
+		c.runAs(killer, charID, func(char *field.Character) {
+			level := char.Level()
+			char.GainEXP(exp)
+			char.Write(packets.MessageIncEXP(exp, false))
+			if char.Level() != level {
+				c.World().UpdatePartyMember(char, c.ID())
+				c.World().UpdateGuildMember(char)
+			}
+		})
+	}
+}
+
+// runAs runs fn with a character on this channel from its own client goroutine. It
+// runs right away when that is the calling client, and is queued otherwise.
+func (c *Channel) runAs(from *Client, charID uint, fn func(*field.Character)) {
+	client, ok := c.GetClient(charID)
+	if !ok {
+		return
+	}
+	if client == from {
+		if char := client.character; char != nil {
+			fn(char)
+		}
+		return
+	}
+	client.Do(func() {
+		if char := client.character; char != nil && char.ID() == charID {
+			fn(char)
+		}
+	})
+}
+
+// sharePartyEXP splits a party's pooled EXP between its members on the field, weighted
+// by level. Members who attacked, or are close enough in level to the mob, share it,
+// and each extra member adds a bonus.
+func sharePartyEXP(f *field.Field, mob *field.Mob, partyID uint, exp float64, attackers map[uint]int64, shares map[uint]float64) {
+	var members []*field.Character
+	var levels int64
+	for _, char := range f.GetAllCharacters() {
+		if char.PartyID() != partyID || char.HP() <= 0 {
+			continue
+		}
+		_, attacked := attackers[char.ID()]
+		if !attacked && int32(char.Level()) < mob.Level()-partyEXPLevelRange {
+			continue
+		}
+		members = append(members, char)
+		levels += int64(char.Level())
+	}
+	if levels == 0 {
+		return
+	}
+
+	exp *= 1 + partyEXPBonusRate*float64(len(members)-1)
+	for _, char := range members {
+		shares[char.ID()] += exp * float64(char.Level()) / float64(levels)
+	}
+}
+
+// updateMobKillQuests advances the character's kill-count quests for a mob
+func updateMobKillQuests(char *field.Character, mobID int32) {
+	qm := char.QuestManager()
+	if qm == nil {
+		return
+	}
+
+	for _, questID := range qm.OnMobKill(mobID) {
+		char.SetQuestRecord(uint16(questID), models.QuestStatePerform, qm.GetProgressString(questID))
+		if err := char.Write(packets.MessageQuestRecordStarted(questID, qm.GetClientProgress(questID))); err != nil {
+			log.Printf("[Quest] Failed to send progress of quest %d to %s: %v", questID, char.Name(), err)
+		}
+	}
+}
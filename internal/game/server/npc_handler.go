@@ -0,0 +1,230 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	dataquest "github.com/Jinw00Arise/Jinwoo/internal/data/quest"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/script"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+// npcTalkRange is the maximum distance in pixels between a character and the NPC it talks to
+const npcTalkRange = 1000
+
+func (h *ChannelHandler) handleUserSelectNpc(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	objectID := int32(reader.ReadInt())
+	_ = reader.ReadShort() // user x
+	_ = reader.ReadShort() // user y
+
+	currentField := character.Field()
+	if currentField == nil {
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	npc := currentField.GetNPC(objectID)
+	if npc == nil {
+		log.Printf("[NPC] %s selected unknown NPC object %d on map %d", character.Name(), objectID, currentField.ID())
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	if !inNPCRange(character, npc) {
+		log.Printf("[NPC] %s is too far from NPC %d to talk", character.Name(), npc.TemplateID())
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	server := h.client.server
+	conversations := server.ScriptManager().Conversations()
+	if conversations.HasConversation(character.ID()) {
+		// Still talking to someone else; the client will retry once that dialog closes
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	npcID := npc.TemplateID()
+	scriptName := strconv.Itoa(int(npcID))
+	if npcProvider := server.NPCProvider(); npcProvider != nil {
+		if name := npcProvider.GetNPCScript(npcID); name != "" {
+			scriptName = name
+		}
+	}
+
+	scriptChar := NewScriptCharacter(character, h.client.Channel(), h.client)
+	ctx := script.NewNPCContext(scriptChar, npcID, scriptName)
+	ctx.ObjectID = objectID
+
+	client := h.client
+	ctx.OnComplete = func() {
+		client.Write(packets.EnableActions())
+	}
+
+	scriptMgr := server.ScriptManager()
+	if scriptMgr.ScriptExists(script.ScriptTypeNPC, scriptName) {
+		if err := scriptMgr.ExecuteNPCScript(ctx); err != nil {
+			log.Printf("[NPC] Script error for NPC %d (%s): %v", npcID, scriptName, err)
+			h.client.Write(packets.EnableActions())
+		}
+		return
+	}
+
+	if !h.openQuestDialog(character, ctx) {
+		log.Printf("[NPC] No script or quests for NPC %d (%s)", npcID, scriptName)
+		h.client.Write(packets.EnableActions())
+	}
+}
+
+// inNPCRange reports whether the character is close enough to talk to the NPC
+func inNPCRange(character *field.Character, npc *field.NPC) bool {
+	cx, cy := character.Position()
+	nx, ny := npc.Position()
+	dx := int(int16(cx)) - int(int16(nx))
+	dy := int(int16(cy)) - int(int16(ny))
+	return dx*dx+dy*dy <= npcTalkRange*npcTalkRange
+}
+
+// npcQuestOption is a quest offered or completable in the NPC quest dialog
+type npcQuestOption struct {
+	quest    *dataquest.QuestData
+	complete bool
+}
+
+// openQuestDialog starts a built-in conversation offering the quests the NPC can start
+// or complete for this character. Returns false if there is nothing to offer.
+func (h *ChannelHandler) openQuestDialog(character *field.Character, ctx *script.NPCContext) bool {
+	options := h.npcQuestOptions(character, ctx.NPCID)
+	if len(options) == 0 {
+		return false
+	}
+
+	h.client.server.ScriptManager().StartNPCConversation(ctx, func() {
+		option := options[0]
+		if len(options) > 1 {
+			var menu strings.Builder
+			menu.WriteString("What would you like to do?#b")
+			for i, o := range options {
+				fmt.Fprintf(&menu, "\r\n#L%d#%s#l", i, questDialogName(o.quest))
+			}
+			selection := ctx.AskMenu(menu.String())
+			if selection < 0 || selection >= len(options) {
+				return
+			}
+			option = options[selection]
+		}
+
+		if option.complete {
+			h.runQuestCompleteDialog(character, ctx, option.quest)
+		} else {
+			h.runQuestStartDialog(character, ctx, option.quest)
+		}
+	})
+
+	return true
+}
+
+// npcQuestOptions lists completable quests first, then startable ones, ordered by quest ID
+func (h *ChannelHandler) npcQuestOptions(character *field.Character, npcID int32) []npcQuestOption {
+	qm := character.QuestManager()
+	questProvider := h.client.server.QuestProvider()
+	if qm == nil || questProvider == nil {
+		return nil
+	}
+
+	var completable, startable []npcQuestOption
+	for _, qd := range questProvider.GetQuestsCompletableAtNPC(npcID) {
+		if qd.Info == nil {
+			continue
+		}
+		if qm.CheckCompleteRequirements(qd.Info.QuestID, int32(character.Level()), int32(character.Job())).CanComplete {
+			completable = append(completable, npcQuestOption{quest: qd, complete: true})
+		}
+	}
+	for _, qd := range questProvider.GetQuestsByNPC(npcID) {
+		if qd.Info == nil || qd.Info.Blocked {
+			continue
+		}
+		if qm.CheckStartRequirements(qd.Info.QuestID, int32(character.Level()), int32(character.Job()), int32(character.Fame())).CanStart {
+			startable = append(startable, npcQuestOption{quest: qd})
+		}
+	}
+
+	byID := func(opts []npcQuestOption) {
+		sort.Slice(opts, func(i, j int) bool {
+			return opts[i].quest.Info.QuestID < opts[j].quest.Info.QuestID
+		})
+	}
+	byID(completable)
+	byID(startable)
+
+	return append(completable, startable...)
+}
+
+// runQuestStartDialog plays a quest's Say.img start dialogue and starts it if accepted
+func (h *ChannelHandler) runQuestStartDialog(character *field.Character, ctx *script.NPCContext, qd *dataquest.QuestData) {
+	questID := qd.Info.QuestID
+
+	prompt := questDialogName(qd)
+	var yes, no []string
+	if say := qd.SayStart; say != nil {
+		if n := len(say.Messages); n > 0 {
+			for _, msg := range say.Messages[:n-1] {
+				ctx.SayNext(msg)
+			}
+			prompt = say.Messages[n-1]
+		}
+		yes, no = say.Yes, say.No
+	}
+
+	if !ctx.AskAccept(prompt) {
+		for _, msg := range no {
+			ctx.Say(msg)
+		}
+		return
+	}
+
+	// The dialog runs on the conversation goroutine; the quest is started on the client's
+	started := false
+	h.client.Call(func() {
+		h.startQuest(character, questID, ctx.NPCID)
+		started = character.QuestManager().GetQuestState(questID) == dataquest.QuestStatePerform
+	})
+	if !started {
+		return
+	}
+	for _, msg := range yes {
+		ctx.SayNext(msg)
+	}
+}
+
+// runQuestCompleteDialog plays a quest's Say.img end dialogue and completes it
+func (h *ChannelHandler) runQuestCompleteDialog(character *field.Character, ctx *script.NPCContext, qd *dataquest.QuestData) {
+	if say := qd.SayEnd; say != nil {
+		for _, msg := range say.Messages {
+			ctx.SayNext(msg)
+		}
+	}
+
+	h.client.Call(func() {
+		h.completeQuest(character, qd.Info.QuestID, ctx.NPCID)
+	})
+}
+
+// questDialogName returns the quest's display name for dialog menus
+func questDialogName(qd *dataquest.QuestData) string {
+	if qd.Info.Name != "" {
+		return qd.Info.Name
+	}
+	return fmt.Sprintf("Quest %d", qd.Info.QuestID)
+}
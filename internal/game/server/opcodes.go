@@ -16,6 +16,8 @@ const (
 	RecvCheckDuplicatedID    uint16 = 21
 	RecvCreateNewCharacter   uint16 = 22
 	RecvDeleteCharacter      uint16 = 24
+	RecvEnableSPWRequest     uint16 = 28
+	RecvCheckSPWRequest      uint16 = 29
 	RecvCreateSecurityHandle uint16 = 34
 	RecvClientDumpLog        uint16 = 36
 	RecvUpdateScreenSetting  uint16 = 218
@@ -32,21 +34,49 @@ const (
 	SendCreateNewCharacterResult uint16 = 14
 	SendDeleteCharacterResult    uint16 = 15
 	SendLatestConnectedWorld     uint16 = 24
+	SendCheckSPWResult           uint16 = 27
 )
 
 // Channel opcodes from packets package
 const (
-	RecvMigrateIn                  = packets.RecvMigrateIn
-	RecvUserTransferFieldRequest   = packets.RecvUserTransferFieldRequest
-	RecvUserMove                   = packets.RecvUserMove
-	RecvUserChat                   = packets.RecvUserChat
-	RecvUserScriptMessageAnswer    = packets.RecvUserScriptMessageAnswer
-	RecvUserPortalScriptRequest    = packets.RecvUserPortalScriptRequest
-	RecvUpdateGMBoard              = packets.RecvUpdateGMBoard
-	RecvChannelUpdateScreenSetting = packets.RecvUpdateScreenSetting
-	RecvNpcMove                    = packets.RecvNpcMove
-	RecvRequireFieldObstacleStatus = packets.RecvRequireFieldObstacleStatus
-	RecvCancelInvitePartyMatch     = packets.RecvCancelInvitePartyMatch
+	RecvMigrateIn                       = packets.RecvMigrateIn
+	RecvUserTransferFieldRequest        = packets.RecvUserTransferFieldRequest
+	RecvUserMove                        = packets.RecvUserMove
+	RecvUserMeleeAttack                 = packets.RecvUserMeleeAttack
+	RecvUserShootAttack                 = packets.RecvUserShootAttack
+	RecvUserMagicAttack                 = packets.RecvUserMagicAttack
+	RecvUserChat                        = packets.RecvUserChat
+	RecvUserSelectNpc                   = packets.RecvUserSelectNpc
+	RecvUserScriptMessageAnswer         = packets.RecvUserScriptMessageAnswer
+	RecvUserPortalScriptRequest         = packets.RecvUserPortalScriptRequest
+	RecvGroupMessage                    = packets.RecvGroupMessage
+	RecvWhisper                         = packets.RecvWhisper
+	RecvPartyRequest                    = packets.RecvPartyRequest
+	RecvPartyResult                     = packets.RecvPartyResult
+	RecvGuildRequest                    = packets.RecvGuildRequest
+	RecvGuildResult                     = packets.RecvGuildResult
+	RecvFriendRequest                   = packets.RecvFriendRequest
+	RecvUserChangeSlotPosition          = packets.RecvUserChangeSlotPosition
+	RecvUserStatChangeItemUseRequest    = packets.RecvUserStatChangeItemUseRequest
+	RecvUserStatChangeItemCancelRequest = packets.RecvUserStatChangeItemCancelRequest
+	RecvUserConsumeCashItemUseRequest   = packets.RecvUserConsumeCashItemUseRequest
+	RecvUserPortalScrollUseRequest      = packets.RecvUserPortalScrollUseRequest
+	RecvUserUpgradeItemUseRequest       = packets.RecvUserUpgradeItemUseRequest
+	RecvUserItemReleaseRequest          = packets.RecvUserItemReleaseRequest
+	RecvUserAbilityUpRequest            = packets.RecvUserAbilityUpRequest
+	RecvUserAbilityMassUpRequest        = packets.RecvUserAbilityMassUpRequest
+	RecvUserSkillUpRequest              = packets.RecvUserSkillUpRequest
+	RecvUserSkillUseRequest             = packets.RecvUserSkillUseRequest
+	RecvUserSkillCancelRequest          = packets.RecvUserSkillCancelRequest
+	RecvUserQuestRequest                = packets.RecvUserQuestRequest
+	RecvUserMacroSysDataModified        = packets.RecvUserMacroSysDataModified
+	RecvUpdateGMBoard                   = packets.RecvUpdateGMBoard
+	RecvChannelUpdateScreenSetting      = packets.RecvUpdateScreenSetting
+	RecvNpcMove                         = packets.RecvNpcMove
+	RecvMobMove                         = packets.RecvMobMove
+	RecvDropPickUpRequest               = packets.RecvDropPickUpRequest
+	RecvRequireFieldObstacleStatus      = packets.RecvRequireFieldObstacleStatus
+	RecvCancelInvitePartyMatch          = packets.RecvCancelInvitePartyMatch
 )
 
 const (
@@ -55,10 +85,15 @@ const (
 	SendUserLeaveField      = packets.SendUserLeaveField
 	SendUserChat            = packets.SendUserChat
 	SendUserMove            = packets.SendUserMove
+	SendUserAvatarModified  = packets.SendUserAvatarModified
+	SendUserMeleeAttack     = packets.SendUserMeleeAttack
+	SendUserShootAttack     = packets.SendUserShootAttack
+	SendUserMagicAttack     = packets.SendUserMagicAttack
 	SendNpcEnterField       = packets.SendNpcEnterField
 	SendNpcLeaveField       = packets.SendNpcLeaveField
 	SendNpcChangeController = packets.SendNpcChangeController
 	SendNpcMove             = packets.SendNpcMove
+	SendMobMove             = packets.SendMobMove
 	SendMigrateCommand      = packets.SendMigrateCommand
 )
 
@@ -76,6 +111,8 @@ var LoginRecvOpcodeNames = map[uint16]string{
 	RecvCheckDuplicatedID:    "CheckDuplicatedID",
 	RecvCreateNewCharacter:   "CreateNewCharacter",
 	RecvDeleteCharacter:      "DeleteCharacter",
+	RecvEnableSPWRequest:     "EnableSPWRequest",
+	RecvCheckSPWRequest:      "CheckSPWRequest",
 	RecvCreateSecurityHandle: "CreateSecurityHandle",
 	RecvClientDumpLog:        "ClientDumpLog",
 	RecvUpdateScreenSetting:  "UpdateScreenSetting",
@@ -92,6 +129,7 @@ var LoginSendOpcodeNames = map[uint16]string{
 	SendCreateNewCharacterResult: "CreateNewCharacterResult",
 	SendDeleteCharacterResult:    "DeleteCharacterResult",
 	SendLatestConnectedWorld:     "LatestConnectedWorld",
+	SendCheckSPWResult:           "CheckSPWResult",
 }
 
 // LoginIgnoredRecvOpcodes are opcodes to not log
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
 	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
 	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
 	"github.com/Jinw00Arise/Jinwoo/internal/utils"
 )
@@ -24,9 +25,20 @@ const (
 	LoginResultAlreadyConnected     byte = 7
 	LoginResultNotConnectableWorld  byte = 8
 	LoginResultUnknown              byte = 9
+	LoginResultInvalidBirthDate     byte = 18
+	LoginResultIncorrectSPW         byte = 20
+	LoginResultDeleteGuildMaster    byte = 22
+	LoginResultDeleteEngaged        byte = 24
 	LoginResultInvalidCharacterName byte = 30
 )
 
+// Secondary password (PIC) modes sent as bLoginOpt with the character list
+const (
+	LoginOptRegisterSPW byte = 0 // Client asks for a new PIC on selection
+	LoginOptCheckSPW    byte = 1 // Client asks for the PIC on selection
+	LoginOptNoSPW       byte = 2 // Client selects without a PIC
+)
+
 // Duplicated ID check result codes
 const (
 	DuplicatedIDCheckSuccess   byte = 0
@@ -130,7 +142,7 @@ func SelectWorldResultFailed(reason byte) protocol.Packet {
 }
 
 // SelectWorldResultSuccess builds a successful world select response
-func SelectWorldResultSuccess(characters []*models.Character, equipsByChar map[uint][]*models.CharacterItem, charSlots int) protocol.Packet {
+func SelectWorldResultSuccess(characters []*models.Character, equipsByChar map[uint][]*models.CharacterItem, loginOpt byte, charSlots int) protocol.Packet {
 	p := protocol.NewWithOpcode(SendSelectWorldResult)
 	p.WriteByte(LoginResultSuccess)
 	p.WriteByte(byte(len(characters)))
@@ -141,7 +153,7 @@ func SelectWorldResultSuccess(characters []*models.Character, equipsByChar map[u
 		p.WriteByte(0) // hasRank
 	}
 
-	p.WriteByte(2) // bLoginOpt
+	p.WriteByte(loginOpt) // bLoginOpt
 	p.WriteInt(int32(charSlots))
 	p.WriteInt(0) // nBuyCharCount
 	return p
@@ -169,6 +181,29 @@ func CreateNewCharacterResultSuccess(char *models.Character, equips []*models.Ch
 	return p
 }
 
+// DeleteCharacterResult builds a character deletion response
+func DeleteCharacterResult(characterID int32, result byte) protocol.Packet {
+	p := protocol.NewWithOpcode(SendDeleteCharacterResult)
+	p.WriteInt(characterID)
+	p.WriteByte(result)
+	return p
+}
+
+// SelectCharacterResultFailed builds a failed character selection response
+func SelectCharacterResultFailed(reason byte) protocol.Packet {
+	p := protocol.NewWithOpcode(SendSelectCharacterResult)
+	p.WriteByte(reason)
+	p.WriteByte(0)
+	return p
+}
+
+// CheckSPWResult tells the client the PIC it entered was wrong
+func CheckSPWResult() protocol.Packet {
+	p := protocol.NewWithOpcode(SendCheckSPWResult)
+	p.WriteByte(0)
+	return p
+}
+
 // MigrateCommandResult builds a migration command packet (for login->channel)
 func MigrateCommandResult(host string, port int, characterID int32) protocol.Packet {
 	p := protocol.NewWithOpcode(SendSelectCharacterResult)
@@ -226,6 +261,12 @@ func WriteAvatarData(char *models.Character, equips []*models.CharacterItem, p *
 	p.WriteByte(char.SpawnPoint)    // nPortal
 	p.WriteInt(0)                   // nPlayTime
 	p.WriteShort(0)                 // nSubJob
+
+	writeAvatarLook(char, equips, p)
+}
+
+// writeAvatarLook writes an AvatarLook structure
+func writeAvatarLook(char *models.Character, equips []*models.CharacterItem, p *protocol.Packet) {
 	p.WriteByte(char.Gender)
 	p.WriteByte(char.SkinColor)
 	p.WriteInt(char.Face)
@@ -267,13 +308,13 @@ func writeLookEquips(char *models.Character, equips []*models.CharacterItem, p *
 }
 
 // SetField builds a SetField packet for entering a map
-func SetField(char *models.Character, channelID int, fieldKey byte, items []*models.CharacterItem, quests []*models.QuestRecord) protocol.Packet {
+func SetField(character *field.Character, channelID int) protocol.Packet {
 	p := protocol.NewWithOpcode(SendSetField)
 
 	p.WriteShort(0)
 	p.WriteInt(int32(channelID))
 	p.WriteInt(0)
-	p.WriteByte(fieldKey)
+	p.WriteByte(character.FieldKey())
 	p.WriteByte(1) // bCharacterData
 	p.WriteShort(0)
 
@@ -282,25 +323,27 @@ func SetField(char *models.Character, channelID int, fieldKey byte, items []*mod
 		p.WriteInt(int32(rand.Uint32()))
 	}
 
-	writeCharacterDataFull(&p, char, items, quests)
+	writeCharacterDataFull(&p, character)
 
 	p.WriteInt(0) // bPredictQuit
 	p.WriteInt(0)
 	p.WriteInt(0)
 	p.WriteInt(0)
 
-	writeFT(&p, time.Time{})
+	packets.WriteFT(&p, time.Time{})
 
 	return p
 }
 
-func writeCharacterDataFull(p *protocol.Packet, char *models.Character, items []*models.CharacterItem, quests []*models.QuestRecord) {
+func writeCharacterDataFull(p *protocol.Packet, character *field.Character) {
+	char := character.Model()
+
 	p.WriteLong(0xFFFFFFFFFFFFFFFF)
 	p.WriteByte(0)
 	p.WriteBool(false)
 
 	writeCharacterStat(p, char)
-	p.WriteByte(20)
+	p.WriteByte(byte(character.FriendMax()))
 	p.WriteBool(false)
 
 	p.WriteInt(int32(char.Meso))
@@ -311,15 +354,15 @@ func writeCharacterDataFull(p *protocol.Packet, char *models.Character, items []
 	p.WriteByte(24)
 	p.WriteByte(24)
 
-	writeFT(p, time.Time{})
+	packets.WriteFT(p, time.Time{})
 
-	writeInventoryBlocks(p, items)
+	writeInventoryBlocks(p, character.Items())
 
-	p.WriteShort(0) // SKILLRECORD
-	p.WriteShort(0) // SKILLCOOLTIME
+	packets.WriteSkillRecords(p, character.Skills())
+	packets.WriteSkillCooltimes(p, character.Cooldowns())
 
 	// QUESTRECORD - quests in progress
-	writeQuestRecords(p, quests)
+	writeQuestRecords(p, character.QuestRecords())
 
 	// MINIGAMERECORD
 	p.WriteShort(2)
@@ -380,9 +423,9 @@ func writeQuestRecords(p *protocol.Packet, quests []*models.QuestRecord) {
 		p.WriteShort(q.QuestID)
 		// Write completion time as FILETIME
 		if q.CompletedAt != nil {
-			writeFT(p, *q.CompletedAt)
+			packets.WriteFT(p, *q.CompletedAt)
 		} else {
-			writeFT(p, time.Time{})
+			packets.WriteFT(p, time.Time{})
 		}
 	}
 }
@@ -447,7 +490,7 @@ func writeInventoryBlocks(p *protocol.Packet, items []*models.CharacterItem) {
 		if it.Slot < 0 && it.Slot > -100 {
 			bodyPart := uint16(-it.Slot)
 			p.WriteShort(bodyPart)
-			encodeItem(p, it)
+			packets.EncodeItem(p, it)
 		}
 	}
 	p.WriteShort(0)
@@ -457,7 +500,7 @@ func writeInventoryBlocks(p *protocol.Packet, items []*models.CharacterItem) {
 		if it.Slot <= -100 && it.Slot > -200 {
 			bodyPart := uint16(-(it.Slot + 100))
 			p.WriteShort(bodyPart)
-			encodeItem(p, it)
+			packets.EncodeItem(p, it)
 		}
 	}
 	p.WriteShort(0)
@@ -465,7 +508,7 @@ func writeInventoryBlocks(p *protocol.Packet, items []*models.CharacterItem) {
 	// Equip inventory
 	for _, it := range equipInv {
 		p.WriteShort(uint16(int16(it.Slot)))
-		encodeItem(p, it)
+		packets.EncodeItem(p, it)
 	}
 	p.WriteShort(0)
 
@@ -478,158 +521,62 @@ func writeInventoryBlocks(p *protocol.Packet, items []*models.CharacterItem) {
 	// ITEMSLOTCONSUME
 	for _, it := range consume {
 		p.WriteByte(byte(it.Slot))
-		encodeItem(p, it)
+		packets.EncodeItem(p, it)
 	}
 	p.WriteByte(0)
 
 	// ITEMSLOTINSTALL
 	for _, it := range install {
 		p.WriteByte(byte(it.Slot))
-		encodeItem(p, it)
+		packets.EncodeItem(p, it)
 	}
 	p.WriteByte(0)
 
 	// ITEMSLOTETC
 	for _, it := range etcInv {
 		p.WriteByte(byte(it.Slot))
-		encodeItem(p, it)
+		packets.EncodeItem(p, it)
 	}
 	p.WriteByte(0)
 
 	// ITEMSLOTCASH
 	for _, it := range cashInv {
 		p.WriteByte(byte(it.Slot))
-		encodeItem(p, it)
+		packets.EncodeItem(p, it)
 	}
 	p.WriteByte(0)
 }
 
-func encodeItem(p *protocol.Packet, it *models.CharacterItem) {
-	itemType := utils.GetItemTypeByItemID(it.ItemID)
-	p.WriteByte(byte(itemType))
-
-	p.WriteInt(it.ItemID)
-
-	isCash := it.Cash
-	if isCash {
-		p.WriteByte(1)
-		p.WriteLong(uint64(it.ItemSN))
-	} else {
-		p.WriteByte(0)
-	}
-
-	writeExpireTime(p, it.ExpireAt)
-
-	switch itemType {
-	case utils.ItemTypeEquip:
-		encodeEquipData(p, it)
-	case utils.ItemTypePet:
-		encodePetData(p, it)
-	default:
-		p.WriteShort(uint16(it.Quantity))
-		p.WriteString(it.Owner)
-		p.WriteShort(uint16(it.Attribute))
-
-		if utils.IsRechargeableItem(it.ItemID) {
-			p.WriteLong(uint64(it.ItemSN))
-		}
-	}
-}
-
-func encodeEquipData(p *protocol.Packet, it *models.CharacterItem) {
-	p.WriteByte(it.RUC)
-	p.WriteByte(it.CUC)
-
-	p.WriteShort(uint16(it.IncStr))
-	p.WriteShort(uint16(it.IncDex))
-	p.WriteShort(uint16(it.IncInt))
-	p.WriteShort(uint16(it.IncLuk))
-	p.WriteShort(uint16(it.IncMaxHP))
-	p.WriteShort(uint16(it.IncMaxMP))
-	p.WriteShort(uint16(it.IncPAD))
-	p.WriteShort(uint16(it.IncMAD))
-	p.WriteShort(uint16(it.IncPDD))
-	p.WriteShort(uint16(it.IncMDD))
-	p.WriteShort(uint16(it.IncACC))
-	p.WriteShort(uint16(it.IncEVA))
-	p.WriteShort(uint16(it.IncCraft))
-	p.WriteShort(uint16(it.IncSpeed))
-	p.WriteShort(uint16(it.IncJump))
-
-	p.WriteString(it.Owner)
-	p.WriteShort(uint16(it.Attribute))
-
-	p.WriteByte(it.LevelUpType)
-	p.WriteByte(it.Level)
-	p.WriteInt(it.Exp)
-	p.WriteInt(it.Durability)
-
-	p.WriteInt(it.IUC)
-	p.WriteByte(it.Grade)
-	p.WriteByte(it.CHUC)
-
-	p.WriteShort(uint16(it.Option1))
-	p.WriteShort(uint16(it.Option2))
-	p.WriteShort(uint16(it.Option3))
-	p.WriteShort(uint16(it.Socket1))
-	p.WriteShort(uint16(it.Socket2))
-
-	if !it.Cash {
-		p.WriteLong(uint64(it.ItemSN))
-	}
-
-	writeFTZero(p)
-	p.WriteInt(0)
+// UserMove builds a user movement packet
+func UserMove(characterID uint, movePath *field.MovePath) protocol.Packet {
+	p := protocol.NewWithOpcode(SendUserMove)
+	p.WriteInt(int32(characterID))
+	movePath.Encode(&p)
+	return p
 }
 
-func encodePetData(p *protocol.Packet, it *models.CharacterItem) {
-	p.WriteStringWithLength(it.PetName, 13)
-	p.WriteByte(it.PetLevel)
-	p.WriteShort(uint16(it.PetTameness))
-	p.WriteByte(it.PetFullness)
-	writeExpireTime(p, it.ExpireAt)
-	p.WriteShort(uint16(it.PetAttribute))
-	p.WriteShort(uint16(it.PetSkill))
-	p.WriteInt(it.RemainLife)
-	p.WriteShort(uint16(it.Attribute))
-}
-
-// writeExpireTime writes an expiration time, handling nil pointers for permanent items
-func writeExpireTime(p *protocol.Packet, t *time.Time) {
-	if t == nil {
-		writeFT(p, time.Time{})
-	} else {
-		writeFT(p, *t)
+// MobMove builds a packet relaying a controller's mob movement to other players
+func MobMove(move *field.MobMoveInfo) protocol.Packet {
+	p := protocol.NewWithOpcode(SendMobMove)
+	p.WriteInt(move.ObjectID)
+	p.WriteBool(false) // bNotForceLandingWhenDiscard
+	p.WriteBool(false) // bNotChangeAction
+	p.WriteBool(move.NextAttackPossible())
+	p.WriteByte(move.ActionAndDir)
+	p.WriteInt(move.TargetInfo)
+
+	p.WriteInt(int32(len(move.MultiTarget)))
+	for _, pt := range move.MultiTarget {
+		p.WriteInt(pt.X)
+		p.WriteInt(pt.Y)
 	}
-}
-
-func writeFTZero(p *protocol.Packet) {
-	p.WriteInt(0)
-	p.WriteInt(0)
-}
-
-const DefaultTime uint64 = 150842304000000000
 
-func writeFT(p *protocol.Packet, t time.Time) {
-	if t.IsZero() {
-		// Use DefaultTime for permanent/non-expiring items
-		p.WriteLong(DefaultTime)
-		return
+	p.WriteInt(int32(len(move.RandTimes)))
+	for _, t := range move.RandTimes {
+		p.WriteInt(t)
 	}
-	// Convert Unix time to Windows FILETIME
-	// FILETIME epoch is January 1, 1601
-	// Unix epoch is January 1, 1970
-	// Difference is 116444736000000000 (100-nanosecond intervals)
-	const unixToFileTime = 116444736000000000
-	ft := uint64(t.UnixNano()/100) + unixToFileTime
-	p.WriteLong(ft)
-}
 
-// UserMove builds a user movement packet
-func UserMove(characterID uint, movePath *field.MovePath) protocol.Packet {
-	p := protocol.NewWithOpcode(SendUserMove)
-	p.WriteInt(int32(characterID))
-	movePath.Encode(&p)
+	move.MovePath.Encode(&p)
 	return p
 }
 
@@ -652,13 +599,13 @@ func UserEnterField(char *field.Character) protocol.Packet {
 	p.WriteString(model.Name)
 
 	// Guild Info
-	p.WriteString("")
-	p.WriteShort(0)
-	p.WriteByte(0)
-	p.WriteShort(0)
-	p.WriteByte(0)
+	p.WriteString(char.GuildName())
+	packets.WriteGuildMark(&p, char.GuildMark())
+
+	packets.WriteTemporaryStatsRemote(&p, char.TemporaryStats())
 
 	p.WriteShort(uint16(model.Job))
+	writeAvatarLook(model, char.Equipped(), &p)
 
 	p.WriteInt(0) // dwDriverID
 	p.WriteInt(0) // dwPassenserID
@@ -698,6 +645,58 @@ func UserEnterField(char *field.Character) protocol.Packet {
 	return p
 }
 
+// UserAvatarModified builds a packet that refreshes a character's look for other players
+func UserAvatarModified(char *field.Character) protocol.Packet {
+	p := protocol.NewWithOpcode(SendUserAvatarModified)
+	p.WriteInt(int32(char.ID()))
+	p.WriteByte(1) // flag: AvatarLook
+	writeAvatarLook(char.Model(), char.Equipped(), &p)
+	p.WriteBool(false) // couple ring
+	p.WriteBool(false) // friendship ring
+	p.WriteBool(false) // marriage ring
+	p.WriteInt(0)      // nCompletedSetItemID
+	return p
+}
+
+// UserAttack builds a packet that replays a character's attack for other players
+func UserAttack(char *field.Character, attack *field.AttackInfo, skillLevel byte, bulletItemID int32) protocol.Packet {
+	opcode := SendUserMeleeAttack
+	switch attack.Type {
+	case field.AttackTypeShoot:
+		opcode = SendUserShootAttack
+	case field.AttackTypeMagic:
+		opcode = SendUserMagicAttack
+	}
+
+	p := protocol.NewWithOpcode(opcode)
+	p.WriteInt(int32(char.ID()))
+	p.WriteByte(attack.Mask)
+	p.WriteByte(byte(char.Level()))
+	p.WriteByte(skillLevel)
+	if skillLevel > 0 {
+		p.WriteInt(attack.SkillID)
+	}
+	p.WriteByte(attack.Flag)
+	p.WriteShort(attack.ActionAndDir)
+	p.WriteByte(attack.AttackSpeed)
+	p.WriteByte(0) // nMastery
+	p.WriteInt(bulletItemID)
+
+	for _, mob := range attack.Mobs {
+		p.WriteInt(mob.ObjectID)
+		p.WriteByte(mob.HitAction)
+		for _, line := range mob.Lines {
+			p.WriteBool(line.Critical)
+			p.WriteInt(line.Damage)
+		}
+	}
+
+	if field.IsKeydownSkill(attack.SkillID) {
+		p.WriteInt(attack.KeyDown)
+	}
+	return p
+}
+
 // UserLeaveField builds a packet for a character leaving a field
 func UserLeaveField(characterID uint) protocol.Packet {
 	p := protocol.NewWithOpcode(SendUserLeaveField)
@@ -0,0 +1,309 @@
+package server
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+// partyInviteTimeout is how long an unanswered party invite blocks new invites
+const partyInviteTimeout = time.Minute
+
+// partyInvite is an invite waiting for the invited character's answer
+type partyInvite struct {
+	partyID   uint
+	inviterID uint
+	expires   time.Time
+}
+
+// Party is a group of up to six characters in a world. Parties live only in memory,
+// so a character whose party is gone after a restart is dropped from it on login.
+type Party struct {
+	id       uint
+	leaderID uint
+	members  []packets.PartyMember
+	mu       sync.RWMutex
+}
+
+// ID returns the party ID
+func (p *Party) ID() uint {
+	return p.id
+}
+
+// LeaderID returns the character ID of the party leader
+func (p *Party) LeaderID() uint {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.leaderID
+}
+
+// Data returns a snapshot of the party window
+func (p *Party) Data() packets.PartyData {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return packets.PartyData{
+		LeaderID: p.leaderID,
+		Members:  slices.Clone(p.members),
+	}
+}
+
+// Member returns a member's party window entry
+func (p *Party) Member(charID uint) (packets.PartyMember, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, m := range p.members {
+		if m.CharacterID == charID {
+			return m, true
+		}
+	}
+	return packets.PartyMember{}, false
+}
+
+// IsFull reports whether the party has no free slots
+func (p *Party) IsFull() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.members) >= packets.MaxPartyMembers
+}
+
+// addMember adds a member, failing if the party is full
+func (p *Party) addMember(member packets.PartyMember) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.members) >= packets.MaxPartyMembers {
+		return false
+	}
+	p.members = append(p.members, member)
+	return true
+}
+
+// removeMember removes a member, returning false if they weren't in the party
+func (p *Party) removeMember(charID uint) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := len(p.members)
+	p.members = slices.DeleteFunc(p.members, func(m packets.PartyMember) bool {
+		return m.CharacterID == charID
+	})
+	return len(p.members) < n
+}
+
+// updateMember replaces a member's entry, returning false if they aren't in the party
+func (p *Party) updateMember(member packets.PartyMember) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.members {
+		if p.members[i].CharacterID == member.CharacterID {
+			p.members[i] = member
+			return true
+		}
+	}
+	return false
+}
+
+// setLeader hands leadership to a member
+func (p *Party) setLeader(charID uint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.leaderID = charID
+}
+
+// memberIDs returns the character IDs of all members
+func (p *Party) memberIDs() []uint {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	ids := make([]uint, 0, len(p.members))
+	for _, m := range p.members {
+		ids = append(ids, m.CharacterID)
+	}
+	return ids
+}
+
+// partyMemberOf builds the party window entry for an online character
+func partyMemberOf(character *field.Character, channelID byte) packets.PartyMember {
+	return packets.PartyMember{
+		CharacterID: character.ID(),
+		Name:        character.Name(),
+		Job:         character.Job(),
+		Level:       character.Level(),
+		ChannelID:   int32(channelID),
+		MapID:       character.MapID(),
+	}
+}
+
+// CreateParty creates a party led by the given member
+func (w *World) CreateParty(leader packets.PartyMember) *Party {
+	w.partiesMu.Lock()
+	defer w.partiesMu.Unlock()
+
+	w.nextPartyID++
+	party := &Party{
+		id:       w.nextPartyID,
+		leaderID: leader.CharacterID,
+		members:  []packets.PartyMember{leader},
+	}
+	w.parties[party.id] = party
+	return party
+}
+
+// GetParty returns a party by ID
+func (w *World) GetParty(partyID uint) (*Party, bool) {
+	w.partiesMu.RLock()
+	defer w.partiesMu.RUnlock()
+	party, ok := w.parties[partyID]
+	return party, ok
+}
+
+// DisbandParty removes a party and any invites to it
+func (w *World) DisbandParty(partyID uint) {
+	w.partiesMu.Lock()
+	defer w.partiesMu.Unlock()
+	delete(w.parties, partyID)
+	for charID, invite := range w.partyInvites {
+		if invite.partyID == partyID {
+			delete(w.partyInvites, charID)
+		}
+	}
+}
+
+// AddPartyInvite records a pending invite, returning false if the character
+// already has one that hasn't expired
+func (w *World) AddPartyInvite(charID, partyID, inviterID uint) bool {
+	w.partiesMu.Lock()
+	defer w.partiesMu.Unlock()
+	if invite, pending := w.partyInvites[charID]; pending && time.Now().Before(invite.expires) {
+		return false
+	}
+	w.partyInvites[charID] = partyInvite{
+		partyID:   partyID,
+		inviterID: inviterID,
+		expires:   time.Now().Add(partyInviteTimeout),
+	}
+	return true
+}
+
+// TakePartyInvite consumes a pending invite and returns who sent it. It returns
+// false if the character has no live invite to that party.
+func (w *World) TakePartyInvite(charID, partyID uint) (uint, bool) {
+	w.partiesMu.Lock()
+	defer w.partiesMu.Unlock()
+	invite, ok := w.partyInvites[charID]
+	if !ok || invite.partyID != partyID {
+		return 0, false
+	}
+	delete(w.partyInvites, charID)
+	if time.Now().After(invite.expires) {
+		return 0, false
+	}
+	return invite.inviterID, true
+}
+
+// GetClient returns the client of an online character on any channel of the world.
+// Characters mid-migration between channels aren't found.
+func (w *World) GetClient(charID uint) (*Client, bool) {
+	ref, ok := w.GetCharacter(charID)
+	if !ok {
+		return nil, false
+	}
+	channel, ok := w.GetChannel(ref.ChannelID)
+	if !ok {
+		return nil, false
+	}
+	return channel.GetClient(charID)
+}
+
+// FindCharacterByName returns the reference of an online character by name
+func (w *World) FindCharacterByName(name string) (*CharacterRef, bool) {
+	w.charactersMu.RLock()
+	defer w.charactersMu.RUnlock()
+	for _, ref := range w.characters {
+		if strings.EqualFold(ref.CharacterName, name) {
+			return ref, true
+		}
+	}
+	return nil, false
+}
+
+// BroadcastParty sends a packet to every online member of a party
+func (w *World) BroadcastParty(party *Party, p protocol.Packet) {
+	for _, charID := range party.memberIDs() {
+		if client, ok := w.GetClient(charID); ok {
+			_ = client.Write(p)
+		}
+	}
+}
+
+// RemovePartyMember takes a member out of a party and tells the remaining members
+// and the member themselves
+func (w *World) RemovePartyMember(party *Party, charID uint, name string, expelled bool) {
+	if !party.removeMember(charID) {
+		return
+	}
+
+	p := packets.PartyWithdrawn(party.ID(), charID, name, expelled, party.Data())
+	w.BroadcastParty(party, p)
+	if client, ok := w.GetClient(charID); ok {
+		if character := client.Character(); character != nil {
+			character.SetPartyID(0)
+		}
+		_ = client.Write(p)
+	}
+}
+
+// DisbandPartyAndNotify closes a party for all of its online members. Offline
+// members are dropped from it when they next log in.
+func (w *World) DisbandPartyAndNotify(party *Party) {
+	w.DisbandParty(party.ID())
+
+	p := packets.PartyDisbanded(party.ID(), party.LeaderID())
+	for _, charID := range party.memberIDs() {
+		client, ok := w.GetClient(charID)
+		if !ok {
+			continue
+		}
+		if character := client.Character(); character != nil {
+			character.SetPartyID(0)
+		}
+		_ = client.Write(p)
+	}
+}
+
+// UpdatePartyMember refreshes a member's channel, map, level and job in the party
+// window of every member. Characters whose party no longer exists, or who were
+// expelled while offline, are dropped from it.
+func (w *World) UpdatePartyMember(character *field.Character, channelID byte) {
+	partyID := character.PartyID()
+	if partyID == 0 {
+		return
+	}
+
+	party, ok := w.GetParty(partyID)
+	if !ok || !party.updateMember(partyMemberOf(character, channelID)) {
+		character.SetPartyID(0)
+		return
+	}
+
+	w.BroadcastParty(party, packets.PartyLoaded(party.ID(), party.Data()))
+}
+
+// SetPartyMemberOffline greys out a member who logged off in the party window
+func (w *World) SetPartyMemberOffline(character *field.Character) {
+	party, ok := w.GetParty(character.PartyID())
+	if !ok {
+		return
+	}
+
+	member, ok := party.Member(character.ID())
+	if !ok {
+		return
+	}
+	member.ChannelID = packets.PartyChannelOffline
+	party.updateMember(member)
+
+	w.BroadcastParty(party, packets.PartyLoaded(party.ID(), party.Data()))
+}
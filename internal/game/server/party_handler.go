@@ -0,0 +1,220 @@
+package server
+
+import (
+	"log"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/game"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+func (h *ChannelHandler) handlePartyRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	switch request := reader.ReadByte(); request {
+	case packets.PartyRequestCreate:
+		h.createParty(character)
+	case packets.PartyRequestWithdraw:
+		h.withdrawParty(character)
+	case packets.PartyRequestJoin:
+		partyID := uint(reader.ReadInt())
+		h.joinParty(character, partyID)
+	case packets.PartyRequestInvite:
+		name := reader.ReadString()
+		h.inviteParty(character, name)
+	case packets.PartyRequestKick:
+		targetID := uint(reader.ReadInt())
+		h.kickParty(character, targetID)
+	case packets.PartyRequestChangeBoss:
+		targetID := uint(reader.ReadInt())
+		h.changePartyBoss(character, targetID)
+	default:
+		log.Printf("[Party] Unhandled party request %d from %s", request, character.Name())
+	}
+}
+
+// handlePartyResult handles the invited character's answer to a party invite
+func (h *ChannelHandler) handlePartyResult(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	result := reader.ReadByte()
+	partyID := uint(reader.ReadInt())
+
+	switch result {
+	case packets.PartyResultInviteAccepted:
+		h.joinParty(character, partyID)
+	case packets.PartyResultInviteRejected, packets.PartyResultInviteBlocked, packets.PartyResultInviteAlreadyInvited:
+		world := h.client.Channel().World()
+		inviterID, ok := world.TakePartyInvite(character.ID(), partyID)
+		if !ok {
+			return
+		}
+		if inviter, ok := world.GetClient(inviterID); ok {
+			_ = inviter.Write(packets.PartyMessageName(result, character.Name()))
+		}
+	default:
+		log.Printf("[Party] Unhandled party result %d from %s", result, character.Name())
+	}
+}
+
+// createParty makes a new party led by the character
+func (h *ChannelHandler) createParty(character *field.Character) *Party {
+	if character.PartyID() != 0 {
+		h.client.Write(packets.PartyMessage(packets.PartyResultCreateAlreadyJoined))
+		return nil
+	}
+	if game.Job(character.Job()).IsBeginner() {
+		h.client.Write(packets.PartyMessage(packets.PartyResultCreateBeginner))
+		return nil
+	}
+
+	channel := h.client.Channel()
+	party := channel.World().CreateParty(partyMemberOf(character, channel.ID()))
+	character.SetPartyID(party.ID())
+	h.client.Write(packets.PartyCreated(party.ID()))
+
+	log.Printf("[Party] %s created party %d", character.Name(), party.ID())
+	return party
+}
+
+// withdrawParty leaves the character's party. A leader leaving disbands it.
+func (h *ChannelHandler) withdrawParty(character *field.Character) {
+	world := h.client.Channel().World()
+	party, ok := world.GetParty(character.PartyID())
+	if !ok {
+		character.SetPartyID(0)
+		h.client.Write(packets.PartyMessage(packets.PartyResultWithdrawNotJoined))
+		return
+	}
+
+	if party.LeaderID() == character.ID() {
+		world.DisbandPartyAndNotify(party)
+		log.Printf("[Party] %s disbanded party %d", character.Name(), party.ID())
+		return
+	}
+
+	world.RemovePartyMember(party, character.ID(), character.Name(), false)
+	log.Printf("[Party] %s left party %d", character.Name(), party.ID())
+}
+
+// joinParty adds the character to a party it was invited to
+func (h *ChannelHandler) joinParty(character *field.Character, partyID uint) {
+	channel := h.client.Channel()
+	world := channel.World()
+
+	if _, ok := world.TakePartyInvite(character.ID(), partyID); !ok {
+		log.Printf("[Party] %s tried to join party %d without an invite", character.Name(), partyID)
+		h.client.Write(packets.PartyMessage(packets.PartyResultJoinUnknown))
+		return
+	}
+	if character.PartyID() != 0 {
+		h.client.Write(packets.PartyMessage(packets.PartyResultJoinAlreadyJoined))
+		return
+	}
+
+	party, ok := world.GetParty(partyID)
+	if !ok {
+		h.client.Write(packets.PartyMessage(packets.PartyResultJoinUnknown))
+		return
+	}
+	if !party.addMember(partyMemberOf(character, channel.ID())) {
+		h.client.Write(packets.PartyMessage(packets.PartyResultJoinAlreadyFull))
+		return
+	}
+
+	character.SetPartyID(party.ID())
+	world.BroadcastParty(party, packets.PartyJoined(party.ID(), character.Name(), party.Data()))
+	character.ShowPartyHP()
+
+	log.Printf("[Party] %s joined party %d", character.Name(), party.ID())
+}
+
+// inviteParty invites an online character to the inviter's party, creating one
+// if the inviter isn't in a party yet
+func (h *ChannelHandler) inviteParty(character *field.Character, name string) {
+	world := h.client.Channel().World()
+
+	ref, ok := world.FindCharacterByName(name)
+	var target *field.Character
+	if ok {
+		if client, ok := world.GetClient(ref.CharacterID); ok {
+			target = client.Character()
+		}
+	}
+	if target == nil || target.ID() == character.ID() {
+		h.client.Write(packets.PartyMessage(packets.PartyResultJoinUnknownUser))
+		return
+	}
+	if target.PartyID() != 0 {
+		h.client.Write(packets.PartyMessage(packets.PartyResultJoinAlreadyJoined))
+		return
+	}
+
+	party, ok := world.GetParty(character.PartyID())
+	if !ok {
+		character.SetPartyID(0)
+		if party = h.createParty(character); party == nil {
+			return
+		}
+	}
+	if party.IsFull() {
+		h.client.Write(packets.PartyMessage(packets.PartyResultJoinAlreadyFull))
+		return
+	}
+
+	if !world.AddPartyInvite(target.ID(), party.ID(), character.ID()) {
+		h.client.Write(packets.PartyMessageName(packets.PartyResultInviteAlreadyInvited, target.Name()))
+		return
+	}
+
+	target.Write(packets.PartyInvite(party.ID(), character.Name(), character.Level(), character.Job()))
+	h.client.Write(packets.PartyMessageName(packets.PartyResultInviteSent, target.Name()))
+}
+
+// kickParty expels a member from the leader's party
+func (h *ChannelHandler) kickParty(character *field.Character, targetID uint) {
+	world := h.client.Channel().World()
+	party, ok := world.GetParty(character.PartyID())
+	if !ok || party.LeaderID() != character.ID() || targetID == character.ID() {
+		h.client.Write(packets.PartyMessage(packets.PartyResultKickUnknown))
+		return
+	}
+
+	member, ok := party.Member(targetID)
+	if !ok {
+		h.client.Write(packets.PartyMessage(packets.PartyResultKickUnknown))
+		return
+	}
+
+	world.RemovePartyMember(party, targetID, member.Name, true)
+	log.Printf("[Party] %s expelled %s from party %d", character.Name(), member.Name, party.ID())
+}
+
+// changePartyBoss hands party leadership to a member on the leader's field
+func (h *ChannelHandler) changePartyBoss(character *field.Character, targetID uint) {
+	world := h.client.Channel().World()
+	party, ok := world.GetParty(character.PartyID())
+	if !ok || party.LeaderID() != character.ID() {
+		h.client.Write(packets.PartyMessage(packets.PartyResultChangeBossUnknown))
+		return
+	}
+	if _, ok := party.Member(targetID); !ok {
+		h.client.Write(packets.PartyMessage(packets.PartyResultChangeBossUnknown))
+		return
+	}
+
+	if f := character.Field(); f == nil || f.GetCharacter(targetID) == nil {
+		h.client.Write(packets.PartyMessage(packets.PartyResultChangeBossNotSameField))
+		return
+	}
+
+	party.setLeader(targetID)
+	world.BroadcastParty(party, packets.PartyBossChanged(targetID, false))
+}
@@ -0,0 +1,296 @@
+package server
+
+import (
+	"log"
+	"math/rand/v2"
+
+	dataquest "github.com/Jinw00Arise/Jinwoo/internal/data/quest"
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/script"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+func (h *ChannelHandler) handleUserQuestRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	qm := character.QuestManager()
+	if qm == nil {
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	action := reader.ReadByte()
+	questID := int32(reader.ReadShort())
+
+	switch action {
+	case packets.QuestRequestAcceptQuest:
+		npcID := int32(reader.ReadInt())
+		h.startQuest(character, questID, npcID)
+	case packets.QuestRequestCompleteQuest:
+		npcID := int32(reader.ReadInt())
+		h.completeQuest(character, questID, npcID)
+	case packets.QuestRequestForfeit:
+		h.forfeitQuest(character, questID)
+	case packets.QuestRequestOpenScriptQuest, packets.QuestRequestOpenStartScript:
+		npcID := int32(reader.ReadInt())
+		h.runQuestScript(character, questID, npcID, true)
+	case packets.QuestRequestOpenEndScript:
+		npcID := int32(reader.ReadInt())
+		h.runQuestScript(character, questID, npcID, false)
+	default:
+		log.Printf("[Quest] Unhandled quest action %d for quest %d", action, questID)
+		h.client.Write(packets.EnableActions())
+	}
+}
+
+// startQuest validates and starts a quest, applying its start actions
+func (h *ChannelHandler) startQuest(character *field.Character, questID, npcID int32) {
+	qm := character.QuestManager()
+
+	check := qm.CheckStartRequirements(questID, int32(character.Level()), int32(character.Job()), int32(character.Fame()))
+	if !check.CanStart {
+		log.Printf("[Quest] %s cannot start quest %d: %s", character.Name(), questID, check.Reason)
+		h.client.Write(packets.QuestFailed(packets.QuestResultFailedUnknown))
+		return
+	}
+
+	if failType, ok := h.applyQuestAct(character, qm.GetQuestStartRewards(questID)); !ok {
+		h.client.Write(packets.QuestFailed(failType))
+		return
+	}
+
+	if err := qm.StartQuest(questID); err != nil {
+		log.Printf("[Quest] Failed to start quest %d for %s: %v", questID, character.Name(), err)
+		h.client.Write(packets.QuestFailed(packets.QuestResultFailedUnknown))
+		return
+	}
+
+	character.SetQuestRecord(uint16(questID), models.QuestStatePerform, qm.GetProgressString(questID))
+	h.persistQuest(character, questID, false)
+
+	h.client.Write(packets.MessageQuestRecordStarted(questID, qm.GetClientProgress(questID)))
+	h.client.Write(packets.QuestStarted(questID, npcID))
+	log.Printf("[Quest] %s started quest %d", character.Name(), questID)
+}
+
+// completeQuest validates and completes a quest, handing out its rewards
+func (h *ChannelHandler) completeQuest(character *field.Character, questID, npcID int32) {
+	qm := character.QuestManager()
+
+	check := qm.CheckCompleteRequirements(questID, int32(character.Level()), int32(character.Job()))
+	if !check.CanComplete {
+		log.Printf("[Quest] %s cannot complete quest %d: %s", character.Name(), questID, check.Reason)
+		h.client.Write(packets.QuestFailed(packets.QuestResultFailedUnknown))
+		return
+	}
+
+	// Item requirements are checked against the inventory here
+	if qd := h.client.server.QuestProvider().GetQuest(questID); qd != nil && qd.CheckEnd != nil {
+		for _, req := range qd.CheckEnd.Items {
+			if req.Count > 0 && character.ItemCount(req.ItemID) < int32(req.Count) {
+				log.Printf("[Quest] %s is missing item %d x%d for quest %d", character.Name(), req.ItemID, req.Count, questID)
+				h.client.Write(packets.QuestFailed(packets.QuestResultFailedUnknown))
+				return
+			}
+		}
+	}
+
+	rewards := qm.GetQuestRewards(questID)
+	if failType, ok := h.applyQuestAct(character, rewards); !ok {
+		h.client.Write(packets.QuestFailed(failType))
+		return
+	}
+
+	if err := qm.CompleteQuest(questID); err != nil {
+		log.Printf("[Quest] Failed to complete quest %d for %s: %v", questID, character.Name(), err)
+		h.client.Write(packets.QuestFailed(packets.QuestResultFailedUnknown))
+		return
+	}
+
+	character.SetQuestRecord(uint16(questID), models.QuestStateComplete, qm.GetProgressString(questID))
+	h.persistQuest(character, questID, true)
+
+	var nextQuest int32
+	if rewards != nil {
+		nextQuest = rewards.NextQuest
+	}
+
+	h.client.Write(packets.MessageQuestRecordCompleted(questID))
+	h.client.Write(packets.QuestCompleted(questID, npcID, nextQuest))
+	h.client.Write(packets.UserEffectQuestComplete())
+	log.Printf("[Quest] %s completed quest %d", character.Name(), questID)
+}
+
+// forfeitQuest abandons an in-progress quest
+func (h *ChannelHandler) forfeitQuest(character *field.Character, questID int32) {
+	qm := character.QuestManager()
+
+	if err := qm.ForfeitQuest(questID); err != nil {
+		log.Printf("[Quest] %s cannot forfeit quest %d: %v", character.Name(), questID, err)
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	character.RemoveQuestRecord(uint16(questID))
+
+	if repo := h.client.server.Repos().Quests; repo != nil {
+		if err := repo.DeleteQuestRecord(h.client.server.Context(), character.ID(), uint16(questID)); err != nil {
+			log.Printf("[Quest] Failed to delete quest %d for %s: %v", questID, character.Name(), err)
+		}
+	}
+
+	h.client.Write(packets.MessageQuestRecordRemoved(questID))
+	log.Printf("[Quest] %s forfeited quest %d", character.Name(), questID)
+}
+
+// runQuestScript executes a quest's start or end script
+func (h *ChannelHandler) runQuestScript(character *field.Character, questID, npcID int32, isStart bool) {
+	// Quest scripts run right here on the client's goroutine
+	scriptChar := NewScriptCharacter(character, h.client.Channel(), h.client)
+	scriptChar.inline = true
+	ctx := script.NewQuestContext(scriptChar, questID, npcID)
+	if !isStart {
+		ctx.State = 1
+	}
+
+	if err := h.client.server.ScriptManager().ExecuteQuestScript(ctx, isStart); err != nil {
+		log.Printf("[Quest] Script error for quest %d: %v", questID, err)
+	}
+	h.client.Write(packets.EnableActions())
+}
+
+// persistQuest writes a single quest record through the quest repository
+func (h *ChannelHandler) persistQuest(character *field.Character, questID int32, completed bool) {
+	repo := h.client.server.Repos().Quests
+	if repo == nil {
+		return
+	}
+
+	progress := character.QuestManager().GetProgressString(questID)
+	if err := repo.SaveQuestRecord(h.client.server.Context(), character.ID(), uint16(questID), progress, completed); err != nil {
+		log.Printf("[Quest] Failed to save quest %d for %s: %v", questID, character.Name(), err)
+	}
+}
+
+// applyQuestAct hands out a quest act's rewards. Inventory space and meso costs are
+// checked up front so a failing act leaves the character untouched.
+func (h *ChannelHandler) applyQuestAct(character *field.Character, act *dataquest.QuestAct) (byte, bool) {
+	if act == nil {
+		return 0, true
+	}
+
+	if act.Money < 0 && character.Mesos() < -act.Money {
+		return packets.QuestResultFailedMesos, false
+	}
+
+	items := selectQuestActItems(character, act.Items)
+	gains := make(map[int32]int16)
+	for _, it := range items {
+		if it.Count > 0 {
+			gains[it.ItemID] += it.Count
+		}
+		if it.Count < 0 && character.ItemCount(it.ItemID) < int32(-it.Count) {
+			return packets.QuestResultFailedUnknown, false
+		}
+	}
+	if !character.CanHoldAll(gains) {
+		return packets.QuestResultFailedInventory, false
+	}
+
+	for _, it := range items {
+		if it.Count > 0 {
+			character.GainItem(it.ItemID, it.Count)
+		} else {
+			character.RemoveItem(it.ItemID, -it.Count)
+		}
+		h.client.Write(packets.UserEffectQuestItem(it.ItemID, int32(it.Count)))
+	}
+
+	if act.EXP > 0 {
+		exp := int32(float64(act.EXP) * h.client.server.Config().QuestExpRate)
+		character.GainEXP(exp)
+		h.client.Write(packets.MessageIncEXP(exp, true))
+	}
+
+	if act.Money != 0 {
+		character.GainMesos(act.Money)
+		h.client.Write(packets.MessageIncMoney(act.Money))
+	}
+
+	if act.Pop != 0 {
+		character.GainFame(int16(act.Pop))
+		h.client.Write(packets.MessageIncPOP(act.Pop))
+	}
+
+	for _, sp := range act.SP {
+		if len(sp.Jobs) > 0 && !containsJob(sp.Jobs, int32(character.Job())) {
+			continue
+		}
+		character.GainSP(int16(sp.SPValue))
+		h.client.Write(packets.MessageIncSP(character.Job(), byte(sp.SPValue)))
+	}
+
+	for _, sk := range act.Skills {
+		if len(sk.Jobs) > 0 && !containsJob(sk.Jobs, int32(character.Job())) {
+			continue
+		}
+		// Rewards only ever raise the current level / master level
+		level := max(character.SkillLevel(sk.SkillID), sk.SkillLevel)
+		masterLevel := max(character.MasterLevel(sk.SkillID), sk.MasterLevel)
+		character.SetSkill(sk.SkillID, level, masterLevel)
+	}
+
+	return 0, true
+}
+
+// selectQuestActItems filters an act's items down to the ones this character receives.
+// Items with a probability below 100 are a weighted draw of which exactly one is given;
+// negative probabilities mark player-selected rewards, which aren't supported.
+func selectQuestActItems(character *field.Character, items []*dataquest.QuestActItem) []*dataquest.QuestActItem {
+	var gender int32
+	if model := character.Model(); model != nil {
+		gender = int32(model.Gender)
+	}
+
+	var selected, random []*dataquest.QuestActItem
+	var totalProp int32
+	for _, it := range items {
+		if it.Gender >= 0 && it.Gender != 2 && it.Gender != gender {
+			continue
+		}
+		switch {
+		case it.Prop >= 100:
+			selected = append(selected, it)
+		case it.Prop > 0:
+			random = append(random, it)
+			totalProp += it.Prop
+		}
+	}
+
+	if totalProp > 0 {
+		roll := rand.Int32N(totalProp)
+		for _, it := range random {
+			if roll < it.Prop {
+				selected = append(selected, it)
+				break
+			}
+			roll -= it.Prop
+		}
+	}
+
+	return selected
+}
+
+func containsJob(jobs []int32, job int32) bool {
+	for _, j := range jobs {
+		if j == job {
+			return true
+		}
+	}
+	return false
+}
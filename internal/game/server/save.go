@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+)
+
+const (
+	saveTimeout     = 10 * time.Second
+	saveMaxAttempts = 3
+	saveRetryDelay  = 500 * time.Millisecond
+)
+
+// SaveCharacter flushes a character's stats, inventory, quest records and skills to the database.
+// It must run on the character's own client goroutine; other goroutines use SaveClient.
+func (s *Server) SaveCharacter(char *field.Character) error {
+	if char == nil || char.Model() == nil {
+		return nil
+	}
+
+	snap := char.Snapshot()
+	err := s.saveSnapshot(snap)
+	char.ApplySnapshot(snap)
+	return err
+}
+
+// SaveClient saves a client's character from any goroutine. The character is copied
+// on the client's goroutine and the copy is written from the caller's.
+func (s *Server) SaveClient(client *Client) error {
+	var char *field.Character
+	var snap *field.Snapshot
+	if !client.Call(func() {
+		if char = client.Character(); char != nil && client.State() != ClientStateMigrating {
+			snap = char.Snapshot()
+		}
+	}) || snap == nil {
+		// Disconnected clients are saved on their way out
+		return nil
+	}
+
+	err := s.saveSnapshot(snap)
+	client.Do(func() { char.ApplySnapshot(snap) })
+	return err
+}
+
+// saveSnapshot writes a character snapshot to the database. Failed attempts are
+// retried with a linear backoff before the error is returned.
+func (s *Server) saveSnapshot(snap *field.Snapshot) error {
+	if snap == nil {
+		return nil
+	}
+
+	var err error
+	for attempt := 1; attempt <= saveMaxAttempts; attempt++ {
+		// Not derived from s.ctx so saves still go through while shutting down
+		ctx, cancel := context.WithTimeout(context.Background(), saveTimeout)
+		// Spent SP and the skill levels it bought commit together
+		err = s.repos.Tx.Transaction(ctx, func(ctx context.Context) error {
+			if err := s.repos.Characters.Save(ctx, &snap.Model, snap.Items, snap.QuestRecords); err != nil {
+				return err
+			}
+			if s.repos.Skills == nil {
+				return nil
+			}
+			return s.repos.Skills.Save(ctx, snap.Model.ID, snap.Skills, snap.Macros, snap.Cooldowns)
+		})
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+
+		log.Printf("[Save] Failed to save character %s (ID: %d), attempt %d/%d: %v",
+			snap.Model.Name, snap.Model.ID, attempt, saveMaxAttempts, err)
+
+		if attempt < saveMaxAttempts {
+			time.Sleep(saveRetryDelay * time.Duration(attempt))
+		}
+	}
+
+	return fmt.Errorf("save character %d: %w", snap.Model.ID, err)
+}
+
+// SaveAll saves every character currently in this channel and returns the number of failures
+func (c *Channel) SaveAll() int {
+	c.clientsMu.RLock()
+	clients := make([]*Client, 0, len(c.clients))
+	for _, client := range c.clients {
+		clients = append(clients, client)
+	}
+	c.clientsMu.RUnlock()
+
+	server := c.Server()
+	failed := 0
+	for _, client := range clients {
+		if err := server.SaveClient(client); err != nil {
+			log.Printf("[Save] %v", err)
+			failed++
+		}
+	}
+	return failed
+}
+
+// RunAutosave periodically saves all characters in this channel until ctx is cancelled
+func (c *Channel) RunAutosave(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count := c.GetClientCount()
+			if count == 0 {
+				continue
+			}
+			if failed := c.SaveAll(); failed > 0 {
+				log.Printf("[Save] Channel %d autosave: %d/%d character(s) failed to save", c.channelID, failed, count)
+			}
+		}
+	}
+}
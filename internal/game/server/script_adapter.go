@@ -3,6 +3,7 @@ package server
 import (
 	"log"
 
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
 	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
 	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
 	"github.com/Jinw00Arise/Jinwoo/internal/game/script"
@@ -10,11 +11,13 @@ import (
 )
 
 // ScriptCharacter wraps field.Character to implement script.CharacterAccessor
-// It provides access to channel for field resolution
+// It provides access to channel for field resolution. Scripts run on their own
+// goroutine, so anything that changes the character is run on the client's.
 type ScriptCharacter struct {
 	*field.Character
 	channel *Channel
 	client  *Client
+	inline  bool // The script runs on the client's goroutine itself
 }
 
 // NewScriptCharacter creates a new script character adapter
@@ -29,8 +32,51 @@ func NewScriptCharacter(char *field.Character, channel *Channel, client *Client)
 // Ensure ScriptCharacter implements CharacterAccessor
 var _ script.CharacterAccessor = (*ScriptCharacter)(nil)
 
+// do runs fn on the client's goroutine and waits for it. Returns false if the
+// client disconnected first.
+func (sc *ScriptCharacter) do(fn func()) bool {
+	if sc.inline || sc.client == nil {
+		fn()
+		return true
+	}
+	return sc.client.Call(fn)
+}
+
+// SetMapID sets the map the character is saved on
+func (sc *ScriptCharacter) SetMapID(mapID int32) {
+	sc.do(func() { sc.Character.SetMapID(mapID) })
+}
+
+// GainMesos gives or takes mesos
+func (sc *ScriptCharacter) GainMesos(mesos int32) {
+	sc.do(func() { sc.Character.GainMesos(mesos) })
+}
+
+// GainFame gives or takes fame
+func (sc *ScriptCharacter) GainFame(fame int16) {
+	sc.do(func() { sc.Character.GainFame(fame) })
+}
+
+// GainItem gives the character count of an item if they have room
+func (sc *ScriptCharacter) GainItem(itemID int32, count int16) bool {
+	ok := false
+	sc.do(func() { ok = sc.Character.GainItem(itemID, count) })
+	return ok
+}
+
+// RemoveItem takes count of an item from the character
+func (sc *ScriptCharacter) RemoveItem(itemID int32, count int16) bool {
+	ok := false
+	sc.do(func() { ok = sc.Character.RemoveItem(itemID, count) })
+	return ok
+}
+
 // TransferField warps the character to a different map
 func (sc *ScriptCharacter) TransferField(targetMapID int32, portalName string) {
+	sc.do(func() { sc.transferField(targetMapID, portalName) })
+}
+
+func (sc *ScriptCharacter) transferField(targetMapID int32, portalName string) {
 	if sc.channel == nil {
 		log.Printf("[Script] Cannot warp - no channel reference")
 		return
@@ -47,9 +93,7 @@ func (sc *ScriptCharacter) TransferField(targetMapID int32, portalName string) {
 
 	// Send SetField packet
 	if sc.client != nil {
-		items := sc.Character.Items()
-		quests := sc.Character.QuestRecords()
-		if err := sc.client.Write(SetField(sc.Character.Model(), int(sc.channel.ID()), sc.Character.FieldKey(), items, quests)); err != nil {
+		if err := sc.client.Write(SetField(sc.Character, int(sc.channel.ID()))); err != nil {
 			log.Printf("[Script] Failed to send SetField after warp: %v", err)
 		}
 
@@ -87,6 +131,11 @@ func (sc *ScriptCharacter) sendFieldEntities(targetField *field.Field) {
 	}
 	targetField.AssignControllerToMobs(sc.Character)
 
+	// Send drops already on the ground
+	for _, drop := range targetField.GetVisibleDrops(sc.Character) {
+		sc.Character.Write(packets.DropEnterField(drop, packets.DropEnterOnFoothold, 0))
+	}
+
 	// Send other characters
 	for _, otherChar := range targetField.GetAllCharacters() {
 		if otherChar.ID() != sc.Character.ID() {
@@ -96,9 +145,123 @@ func (sc *ScriptCharacter) sendFieldEntities(targetField *field.Field) {
 
 	// Broadcast entry to others
 	targetField.BroadcastExcept(UserEnterField(sc.Character), sc.Character)
+
+	// Party members see the new map and swap HP bars
+	sc.channel.World().UpdatePartyMember(sc.Character, sc.channel.ID())
+	sc.Character.ShowPartyHP()
 }
 
 // Write sends a packet to the character
 func (sc *ScriptCharacter) Write(p protocol.Packet) error {
 	return sc.Character.Write(p)
 }
+
+// GainEXP gives the character EXP and shows the gain in the chat log
+func (sc *ScriptCharacter) GainEXP(exp int32) {
+	sc.do(func() {
+		sc.Character.GainEXP(exp)
+		if exp > 0 {
+			sc.Character.Write(packets.MessageIncEXP(exp, true))
+		}
+	})
+}
+
+// OpenGuildCreation opens the guild name dialog. The guild is created and the
+// fee taken once the client sends a name.
+func (sc *ScriptCharacter) OpenGuildCreation() bool {
+	ok := false
+	sc.do(func() { ok = sc.openGuildCreation() })
+	return ok
+}
+
+func (sc *ScriptCharacter) openGuildCreation() bool {
+	if sc.GuildID() != 0 || sc.Mesos() < guildCreateCost {
+		return false
+	}
+	sc.channel.World().OpenGuildDialog(sc.ID(), packets.GuildResultInputGuildName)
+	sc.Character.Write(packets.GuildInputName())
+	return true
+}
+
+// OpenGuildMarkChange opens the emblem dialog for a guild master. The emblem is
+// changed and the fee taken once the client sends it.
+func (sc *ScriptCharacter) OpenGuildMarkChange() bool {
+	ok := false
+	sc.do(func() { ok = sc.openGuildMarkChange() })
+	return ok
+}
+
+func (sc *ScriptCharacter) openGuildMarkChange() bool {
+	if sc.GuildID() == 0 || sc.GuildGrade() != models.GuildGradeMaster || sc.Mesos() < guildMarkCost {
+		return false
+	}
+	sc.channel.World().OpenGuildDialog(sc.ID(), packets.GuildResultInputMark)
+	sc.Character.Write(packets.GuildInputMark())
+	return true
+}
+
+// GuildCapacityCost returns the fee for the master's next capacity expansion,
+// or 0 if the guild can't grow any more
+func (sc *ScriptCharacter) GuildCapacityCost() int32 {
+	guild, ok := sc.channel.World().GetGuild(sc.GuildID())
+	if !ok || guild.Capacity() >= guildMaxCapacity {
+		return 0
+	}
+	return guildCapacityCost * (guild.Capacity()/guildCapacityStep - 1)
+}
+
+// IncreaseGuildCapacity buys room for more members in the master's guild
+func (sc *ScriptCharacter) IncreaseGuildCapacity() bool {
+	ok := false
+	sc.do(func() { ok = sc.increaseGuildCapacity() })
+	return ok
+}
+
+func (sc *ScriptCharacter) increaseGuildCapacity() bool {
+	if sc.GuildGrade() != models.GuildGradeMaster {
+		return false
+	}
+	world := sc.channel.World()
+	guild, ok := world.GetGuild(sc.GuildID())
+	cost := sc.GuildCapacityCost()
+	if !ok || cost == 0 || sc.Mesos() < cost {
+		return false
+	}
+
+	if err := guild.update(sc.client.server.Context(), world, func(model *models.Guild) {
+		model.Capacity = min(model.Capacity+guildCapacityStep, guildMaxCapacity)
+	}); err != nil {
+		log.Printf("[Script] Failed to expand guild %d: %v", guild.ID(), err)
+		return false
+	}
+	sc.Character.GainMesos(-cost)
+
+	world.BroadcastGuild(guild, packets.GuildCapacityChanged(guild.ID(), guild.Capacity()))
+	log.Printf("[Script] %s expanded guild %d to %d members", sc.Name(), guild.ID(), guild.Capacity())
+	return true
+}
+
+// DisbandGuild disbands the master's guild
+func (sc *ScriptCharacter) DisbandGuild() bool {
+	ok := false
+	sc.do(func() { ok = sc.disbandGuild() })
+	return ok
+}
+
+func (sc *ScriptCharacter) disbandGuild() bool {
+	if sc.GuildGrade() != models.GuildGradeMaster {
+		return false
+	}
+	world := sc.channel.World()
+	guild, ok := world.GetGuild(sc.GuildID())
+	if !ok {
+		return false
+	}
+
+	if err := world.RemoveGuild(sc.client.server.Context(), guild); err != nil {
+		log.Printf("[Script] Failed to disband guild %d: %v", guild.ID(), err)
+		return false
+	}
+	log.Printf("[Script] %s disbanded guild %d", sc.Name(), guild.ID())
+	return true
+}
@@ -5,14 +5,17 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"strings"
 	"sync"
 
 	"github.com/Jinw00Arise/Jinwoo/internal/data/providers"
 	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
 	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
 	"github.com/Jinw00Arise/Jinwoo/internal/game/script"
 	"github.com/Jinw00Arise/Jinwoo/internal/interfaces"
 	"github.com/Jinw00Arise/Jinwoo/internal/network"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
 )
 
 // OnlineCharacterInfo tracks a character that is currently online
@@ -22,6 +25,7 @@ type OnlineCharacterInfo struct {
 	AccountID     uint
 	WorldID       byte
 	ChannelID     byte
+	InCashShop    bool
 }
 
 // Repositories holds all database repositories
@@ -30,6 +34,11 @@ type Repositories struct {
 	Characters interfaces.CharacterRepo
 	Items      interfaces.ItemsRepo
 	Quests     interfaces.QuestProgressRepo
+	Drops      interfaces.DropRepo
+	Skills     interfaces.SkillRepo
+	Buddies    interfaces.BuddyRepo
+	Guilds     interfaces.GuildRepo
+	Tx         interfaces.Transactor
 }
 
 // Providers holds all data providers
@@ -38,6 +47,8 @@ type Providers struct {
 	Maps   field.MapDataProvider
 	Quests *providers.QuestProvider
 	NPCs   *providers.NPCProvider
+	Mobs   *providers.MobProvider
+	Skills *providers.SkillProvider
 }
 
 // Server is the central coordination point for the entire game server
@@ -63,6 +74,7 @@ type Server struct {
 	repos         Repositories
 	providers     Providers
 	scriptManager *script.Manager
+	dropTable     *DropTable
 
 	// Login server listener
 	loginListener net.Listener
@@ -86,6 +98,7 @@ func NewServer(cfg *Config, repos Repositories, provs Providers) *Server {
 		repos:            repos,
 		providers:        provs,
 		scriptManager:    script.NewManager(cfg.ScriptsPath),
+		dropTable:        NewDropTable(),
 		ctx:              ctx,
 		cancel:           cancel,
 	}
@@ -97,7 +110,7 @@ func NewServer(cfg *Config, repos Repositories, provs Providers) *Server {
 
 		// Initialize channels for this world
 		for _, chCfg := range worldCfg.Channels {
-			channel := NewChannel(world, chCfg.ChannelID, chCfg.Port, provs.Maps)
+			channel := NewChannel(world, chCfg.ChannelID, chCfg.Port, provs.Maps, provs.Mobs)
 			world.AddChannel(channel)
 		}
 	}
@@ -135,6 +148,21 @@ func (s *Server) NPCProvider() *providers.NPCProvider {
 	return s.providers.NPCs
 }
 
+// MobProvider returns the mob data provider
+func (s *Server) MobProvider() *providers.MobProvider {
+	return s.providers.Mobs
+}
+
+// SkillProvider returns the skill data provider
+func (s *Server) SkillProvider() *providers.SkillProvider {
+	return s.providers.Skills
+}
+
+// DropTable returns the mob and reactor drop table
+func (s *Server) DropTable() *DropTable {
+	return s.dropTable
+}
+
 // Context returns the server context
 func (s *Server) Context() context.Context {
 	return s.ctx
@@ -142,6 +170,15 @@ func (s *Server) Context() context.Context {
 
 // Start starts all server listeners (login + all channel listeners)
 func (s *Server) Start() error {
+	if s.repos.Drops != nil {
+		if err := s.dropTable.Load(s.ctx, s.repos.Drops); err != nil {
+			log.Printf("[Server] Failed to load drop table: %v", err)
+		} else {
+			mobs, reactors := s.dropTable.Count()
+			log.Printf("[Server] Loaded drops for %d mobs and %d reactors", mobs, reactors)
+		}
+	}
+
 	// Start login listener
 	loginAddr := fmt.Sprintf("%s:%d", s.config.Host, s.config.LoginPort)
 	ln, err := net.Listen("tcp", loginAddr)
@@ -166,6 +203,14 @@ func (s *Server) Start() error {
 				defer s.wg.Done()
 				ch.AcceptConnections(s.ctx)
 			}(channel)
+
+			if s.config.AutosaveInterval > 0 {
+				s.wg.Add(1)
+				go func(ch *Channel) {
+					defer s.wg.Done()
+					ch.RunAutosave(s.ctx, s.config.AutosaveInterval)
+				}(channel)
+			}
 		}
 	}
 
@@ -186,9 +231,12 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		s.loginListener.Close()
 	}
 
-	// Shutdown all channels
+	// Save online characters and shutdown all channels
 	for _, world := range s.worlds {
 		for _, channel := range world.GetChannels() {
+			if failed := channel.SaveAll(); failed > 0 {
+				log.Printf("[Save] Channel %d: %d character(s) could not be saved on shutdown", channel.ID(), failed)
+			}
 			channel.Shutdown()
 		}
 	}
@@ -317,7 +365,6 @@ func (s *Server) GetClientByAccountID(accountID uint) (*Client, bool) {
 // RegisterCharacterOnline marks a character as online
 func (s *Server) RegisterCharacterOnline(charID uint, charName string, accountID uint, worldID, channelID byte) {
 	s.onlineCharactersMu.Lock()
-	defer s.onlineCharactersMu.Unlock()
 	s.onlineCharacters[charID] = &OnlineCharacterInfo{
 		CharacterID:   charID,
 		CharacterName: charName,
@@ -325,17 +372,25 @@ func (s *Server) RegisterCharacterOnline(charID uint, charName string, accountID
 		WorldID:       worldID,
 		ChannelID:     channelID,
 	}
+	s.onlineCharactersMu.Unlock()
 	log.Printf("[Server] Character %s (ID: %d) is now online on World %d Channel %d", charName, charID, worldID, channelID)
+
+	s.notifyBuddies(charID, int32(channelID), false)
 }
 
 // UnregisterCharacterOnline marks a character as offline
 func (s *Server) UnregisterCharacterOnline(charID uint) {
 	s.onlineCharactersMu.Lock()
-	defer s.onlineCharactersMu.Unlock()
-	if info, ok := s.onlineCharacters[charID]; ok {
+	info, ok := s.onlineCharacters[charID]
+	if ok {
 		log.Printf("[Server] Character %s (ID: %d) is now offline", info.CharacterName, charID)
 		delete(s.onlineCharacters, charID)
 	}
+	s.onlineCharactersMu.Unlock()
+
+	if ok {
+		s.notifyBuddies(charID, packets.FriendChannelOffline, false)
+	}
 }
 
 // IsCharacterOnline checks if a character is currently online
@@ -354,6 +409,70 @@ func (s *Server) GetOnlineCharacter(charID uint) (*OnlineCharacterInfo, bool) {
 	return info, ok
 }
 
+// GetOnlineClient returns the channel client of an online character. It fails
+// while the character is in the cash shop or between channels.
+func (s *Server) GetOnlineClient(charID uint) (*Client, bool) {
+	info, ok := s.GetOnlineCharacter(charID)
+	if !ok || info.InCashShop {
+		return nil, false
+	}
+	channel, ok := s.GetChannel(info.WorldID, info.ChannelID)
+	if !ok {
+		return nil, false
+	}
+	return channel.GetClient(charID)
+}
+
+// SendToCharacter delivers a packet to an online character on any channel. Packets
+// for characters between channels are held and delivered once they arrive.
+// Returns false if the character is offline.
+func (s *Server) SendToCharacter(charID uint, p protocol.Packet) bool {
+	client, ok := s.GetOnlineClient(charID)
+	ok = ok && client.Character() != nil
+	if s.migrations.Hold(charID, p, !ok && s.IsCharacterOnline(charID)) {
+		return true
+	}
+	if !ok {
+		return false
+	}
+	return client.Write(p) == nil
+}
+
+// TakePendingPackets returns and clears the packets held for a character while
+// they were between channels. Packets are sent directly from then on.
+func (s *Server) TakePendingPackets(charID uint) []protocol.Packet {
+	return s.migrations.TakePending(charID)
+}
+
+// FindOnlineCharacter returns online character info by name
+func (s *Server) FindOnlineCharacter(name string) (*OnlineCharacterInfo, bool) {
+	s.onlineCharactersMu.RLock()
+	defer s.onlineCharactersMu.RUnlock()
+	for _, info := range s.onlineCharacters {
+		if strings.EqualFold(info.CharacterName, name) {
+			found := *info
+			return &found, true
+		}
+	}
+	return nil, false
+}
+
+// SetCharacterInCashShop marks an online character as entering or leaving the cash shop
+func (s *Server) SetCharacterInCashShop(charID uint, inCashShop bool) {
+	s.onlineCharactersMu.Lock()
+	info, ok := s.onlineCharacters[charID]
+	var channelID byte
+	if ok {
+		info.InCashShop = inCashShop
+		channelID = info.ChannelID
+	}
+	s.onlineCharactersMu.Unlock()
+
+	if ok {
+		s.notifyBuddies(charID, int32(channelID), inCashShop)
+	}
+}
+
 // GetOnlineCharacterCount returns the total number of online characters
 func (s *Server) GetOnlineCharacterCount() int {
 	s.onlineCharactersMu.RLock()
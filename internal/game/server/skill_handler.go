@@ -0,0 +1,167 @@
+package server
+
+import (
+	"log"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/data/providers"
+	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+// maxMacroNameLength is the longest macro name the client allows
+const maxMacroNameLength = 12
+
+func (h *ChannelHandler) handleUserSkillUpRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	_ = reader.ReadInt() // update time
+	skillID := reader.ReadInt()
+
+	skill := h.client.server.SkillProvider().GetSkill(skillID)
+	if skill == nil {
+		log.Printf("[Skill] %s tried to level unknown skill %d", character.Name(), skillID)
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	if err := character.SkillUp(skill); err != nil {
+		log.Printf("[Skill] %s: skill up %d failed: %v", character.Name(), skillID, err)
+		h.client.Write(packets.EnableActions())
+	}
+}
+
+func (h *ChannelHandler) handleUserMacroSysDataModified(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	count := int(reader.ReadByte())
+	macros := make([]*models.SkillMacro, 0, count)
+	for i := 0; i < count && reader.Remaining() > 0; i++ {
+		name := reader.ReadString()
+		if len(name) > maxMacroNameLength {
+			name = name[:maxMacroNameLength]
+		}
+		macros = append(macros, &models.SkillMacro{
+			Name:   name,
+			Shout:  reader.ReadBool(),
+			Skill1: reader.ReadInt(),
+			Skill2: reader.ReadInt(),
+			Skill3: reader.ReadInt(),
+		})
+	}
+
+	character.SetMacros(macros)
+}
+
+func (h *ChannelHandler) handleUserSkillUseRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	_ = reader.ReadInt() // update time
+	skillID := reader.ReadInt()
+	slv := int32(reader.ReadByte())
+	// Party member mask for area buffs, then affected mobs and delay for some skills
+
+	skill := h.client.server.SkillProvider().GetSkill(skillID)
+	if skill == nil || slv <= 0 || slv > character.SkillLevel(skillID) {
+		log.Printf("[Skill] %s used skill %d at level %d without learning it", character.Name(), skillID, slv)
+		h.client.Write(packets.EnableActions())
+		return
+	}
+	lv := skill.Level(slv)
+	if lv == nil {
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	changed, err := character.UseSkill(skillID, lv)
+	if err != nil {
+		log.Printf("[Skill] %s: skill use %d failed: %v", character.Name(), skillID, err)
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	if stats := field.SkillBuffStats(skillID, lv); len(stats) > 0 {
+		character.SetTemporaryStats(skillID, field.SkillBuffDuration(lv), stats)
+
+		if lv.HasArea() && reader.Remaining() > 0 {
+			h.applyPartyBuff(character, skillID, slv, lv, reader.ReadByte())
+		}
+	}
+
+	h.client.Write(packets.StatChanged(true, changed))
+	if f := character.Field(); f != nil {
+		f.BroadcastExcept(packets.UserEffectRemoteSkillUse(character.ID(), skillID, byte(character.Level()), byte(slv)), character)
+	}
+}
+
+// applyPartyBuff gives a buff skill's stats to the party members picked by the client's
+// member mask who are on the caster's field, alive and inside the skill's area
+func (h *ChannelHandler) applyPartyBuff(character *field.Character, skillID, slv int32, lv *providers.SkillLevel, memberMask byte) {
+	f := character.Field()
+	party, ok := h.client.Channel().World().GetParty(character.PartyID())
+	if f == nil || !ok {
+		return
+	}
+
+	// Bit 5 is the first member in the party window, bit 0 the sixth
+	picked := make(map[uint]bool)
+	for i, m := range party.Data().Members {
+		if memberMask&(1<<(packets.MaxPartyMembers-1-i)) != 0 {
+			picked[m.CharacterID] = true
+		}
+	}
+
+	duration := field.SkillBuffDuration(lv)
+	for _, member := range f.PartyMembers(character) {
+		if !picked[member.ID()] || !inSkillArea(character, member, lv) {
+			continue
+		}
+		h.client.Channel().runAs(h.client, member.ID(), func(member *field.Character) {
+			if member.Field() != f || member.HP() <= 0 {
+				return
+			}
+			member.SetTemporaryStats(skillID, duration, field.SkillBuffStats(skillID, lv))
+			member.Write(packets.UserEffectSkillAffected(skillID, byte(slv)))
+			f.BroadcastExcept(packets.UserEffectRemoteSkillAffected(member.ID(), skillID, byte(slv)), member)
+		})
+	}
+}
+
+// inSkillArea checks a character stands inside the area of a skill cast by the caster.
+// The caster's facing isn't tracked, so the area is mirrored to both sides.
+func inSkillArea(caster, target *field.Character, lv *providers.SkillLevel) bool {
+	cx, cy := caster.Position()
+	tx, ty := target.Position()
+	dx := int32(int16(tx)) - int32(int16(cx))
+	dy := int32(int16(ty)) - int32(int16(cy))
+
+	reach := max(abs32(lv.LTX), abs32(lv.RBX))
+	return abs32(dx) <= reach && dy >= lv.LTY && dy <= lv.RBY
+}
+
+func abs32(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func (h *ChannelHandler) handleUserSkillCancelRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	skillID := reader.ReadInt()
+	character.CancelTemporaryStats(skillID)
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"log"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+func (h *ChannelHandler) handleUserAbilityUpRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	_ = reader.ReadInt() // update time
+	flag := reader.ReadInt()
+
+	h.spendAP(map[int32]int16{flag: 1})
+}
+
+func (h *ChannelHandler) handleUserAbilityMassUpRequest(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	_ = reader.ReadInt() // update time
+	count := int(reader.ReadInt())
+
+	points := make(map[int32]int16)
+	for i := 0; i < count && reader.Remaining() >= 8; i++ {
+		flag := reader.ReadInt()
+		value := reader.ReadInt()
+		if value <= 0 || value > int32(character.Model().AP) {
+			log.Printf("[Stat] %s sent invalid auto-assign of %d AP to 0x%x", character.Name(), value, flag)
+			h.client.Write(packets.EnableActions())
+			return
+		}
+		points[flag] += int16(value)
+	}
+
+	h.spendAP(points)
+}
+
+// spendAP applies an AP distribution and sends the changed stats back
+func (h *ChannelHandler) spendAP(points map[int32]int16) {
+	character := h.client.character
+
+	changed, err := character.SpendAP(points)
+	if err != nil {
+		log.Printf("[Stat] %s: AP distribution failed: %v", character.Name(), err)
+		h.client.Write(packets.EnableActions())
+		return
+	}
+
+	h.client.Write(packets.StatChanged(true, changed))
+}
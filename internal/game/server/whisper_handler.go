@@ -0,0 +1,100 @@
+package server
+
+import (
+	"log"
+
+	"github.com/Jinw00Arise/Jinwoo/internal/game/field"
+	"github.com/Jinw00Arise/Jinwoo/internal/game/packets"
+	"github.com/Jinw00Arise/Jinwoo/internal/protocol"
+)
+
+func (h *ChannelHandler) handleWhisper(reader *protocol.Reader) {
+	character := h.client.character
+	if character == nil {
+		return
+	}
+
+	flag := reader.ReadByte()
+	_ = reader.ReadInt() // update time
+	targetName := reader.ReadString()
+
+	switch flag &^ packets.WhisperFlagManager {
+	case packets.WhisperFlagLocation | packets.WhisperFlagRequest:
+		h.findCharacter(character, targetName, packets.WhisperFlagLocation)
+	case packets.WhisperFlagLocationFriend | packets.WhisperFlagRequest:
+		h.findCharacter(character, targetName, packets.WhisperFlagLocationFriend)
+	case packets.WhisperFlagWhisper | packets.WhisperFlagRequest:
+		text := reader.ReadString()
+		h.whisper(character, targetName, text)
+	default:
+		log.Printf("[Whisper] Unhandled whisper flag 0x%02X from %s", flag, character.Name())
+	}
+}
+
+// whisper delivers a private message to an online character in the sender's world
+func (h *ChannelHandler) whisper(character *field.Character, targetName, text string) {
+	info, target, ok := h.locateCharacter(targetName)
+	if !ok || info.InCashShop || target == nil {
+		h.client.Write(packets.WhisperResult(targetName, false))
+		return
+	}
+	if target.Hidden() {
+		h.client.Write(packets.WhisperBlocked(targetName))
+		return
+	}
+
+	target.Write(packets.WhisperReceive(character.Name(), h.client.Channel().ID(), false, text))
+	h.client.Write(packets.WhisperResult(target.Name(), true))
+}
+
+// findCharacter answers a /find with the target's map, channel or the cash shop
+func (h *ChannelHandler) findCharacter(character *field.Character, targetName string, flag byte) {
+	info, target, ok := h.locateCharacter(targetName)
+	if !ok {
+		h.client.Write(packets.WhisperResult(targetName, false))
+		return
+	}
+
+	if info.InCashShop {
+		h.client.Write(packets.WhisperLocationResult(flag, info.CharacterName, packets.WhisperLocationCashShop, -1, 0, 0))
+		return
+	}
+	if target == nil {
+		// Between channels
+		h.client.Write(packets.WhisperResult(targetName, false))
+		return
+	}
+	if target.Hidden() {
+		h.client.Write(packets.WhisperBlocked(targetName))
+		return
+	}
+
+	if info.ChannelID != h.client.Channel().ID() {
+		h.client.Write(packets.WhisperLocationResult(flag, target.Name(), packets.WhisperLocationOtherChannel, int32(info.ChannelID), 0, 0))
+		return
+	}
+
+	x, y := target.Position()
+	h.client.Write(packets.WhisperLocationResult(flag, target.Name(), packets.WhisperLocationField, target.MapID(), int32(int16(x)), int32(int16(y))))
+}
+
+// locateCharacter finds an online character in the sender's world through the server's
+// online registry. The returned character is nil while the target is in the cash shop
+// or migrating between channels.
+func (h *ChannelHandler) locateCharacter(name string) (*OnlineCharacterInfo, *field.Character, bool) {
+	server := h.client.server
+	info, ok := server.FindOnlineCharacter(name)
+	if !ok || info.WorldID != h.client.Channel().World().ID() {
+		return nil, nil, false
+	}
+
+	channel, ok := server.GetChannel(info.WorldID, info.ChannelID)
+	if !ok {
+		return info, nil, true
+	}
+	client, ok := channel.GetClient(info.CharacterID)
+	if !ok {
+		return info, nil, true
+	}
+	return info, client.Character(), true
+}
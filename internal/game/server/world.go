@@ -24,6 +24,18 @@ type World struct {
 	// World-wide character tracking
 	characters   map[uint]*CharacterRef // charID -> ref
 	charactersMu sync.RWMutex
+
+	// Parties
+	parties      map[uint]*Party
+	partyInvites map[uint]partyInvite // invited charID -> invite
+	nextPartyID  uint
+	partiesMu    sync.RWMutex
+
+	// Guilds
+	guilds       map[uint]*Guild
+	guildInvites map[uint]guildInvite // invited charID -> invite
+	guildDialogs map[uint]guildDialog // charID -> dialog opened by an NPC
+	guildsMu     sync.RWMutex
 }
 
 // NewWorld creates a new world instance
@@ -34,6 +46,13 @@ func NewWorld(server *Server, worldID byte, worldName string) *World {
 		worldName:  worldName,
 		channels:   make(map[byte]*Channel),
 		characters: make(map[uint]*CharacterRef),
+
+		parties:      make(map[uint]*Party),
+		partyInvites: make(map[uint]partyInvite),
+
+		guilds:       make(map[uint]*Guild),
+		guildInvites: make(map[uint]guildInvite),
+		guildDialogs: make(map[uint]guildDialog),
 	}
 }
 
@@ -0,0 +1,24 @@
+package game
+
+// SkillJob returns the job a skill belongs to
+func SkillJob(skillID int32) Job {
+	return Job(skillID / 10000)
+}
+
+// IsSkillNeedMasterLevel returns true for skills whose level is capped by a
+// master level (4th job skills and their Evan/Dual Blade equivalents)
+func IsSkillNeedMasterLevel(skillID int32) bool {
+	job := SkillJob(skillID)
+	switch {
+	case job.IsEvan():
+		return job == JobEvan9 || job == JobEvan10 ||
+			skillID == 22111001 || skillID == 22140000 || skillID == 22141002
+	case job.IsDualBlade():
+		return job == JobBladeMaster ||
+			skillID == 4311003 || skillID == 4321000 || skillID == 4331002 || skillID == 4331005
+	case job%100 == 0:
+		return false
+	default:
+		return job%10 == 2
+	}
+}
@@ -6,11 +6,19 @@ import (
 	"github.com/Jinw00Arise/Jinwoo/internal/database/models"
 )
 
+// Transactor runs repository calls in a single database transaction
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type AccountRepo interface {
 	FindByUsername(ctx context.Context, username string) (*models.Account, error)
 	Create(ctx context.Context, username, password string) (*models.Account, error)
 	VerifyPassword(account *models.Account, password string) bool
 	FindByID(ctx context.Context, id uint) (*models.Account, error)
+	SetPIC(ctx context.Context, account *models.Account, pic string) error
+	UpdatePIC(ctx context.Context, account *models.Account) error
+	VerifyPIC(account *models.Account, pic string) bool
 }
 
 type CharacterRepo interface {
@@ -18,12 +26,16 @@ type CharacterRepo interface {
 	NameExists(ctx context.Context, worldID byte, name string) (bool, error)
 	Create(ctx context.Context, char *models.Character, items []*models.CharacterItem) error
 	FindByID(ctx context.Context, id uint) (*models.Character, error)
+	FindByName(ctx context.Context, worldID byte, name string) (*models.Character, error)
 	Update(ctx context.Context, char *models.Character) error
+	Save(ctx context.Context, char *models.Character, items []*models.CharacterItem, quests []*models.QuestRecord) error
+	Delete(ctx context.Context, id uint) error
 }
 
 type QuestProgressRepo interface {
 	SaveQuestRecord(ctx context.Context, characterID uint, questID uint16, progress string, completed bool) error
 	GetQuestRecords(ctx context.Context, characterID uint) ([]*models.QuestRecord, error)
+	DeleteQuestRecord(ctx context.Context, characterID uint, questID uint16) error
 }
 
 type ItemsRepo interface {
@@ -31,3 +43,33 @@ type ItemsRepo interface {
 	GetEquippedByCharacterIDs(ctx context.Context, characterIDs []uint) (map[uint][]*models.CharacterItem, error)
 	GetByCharacterID(ctx context.Context, characterID uint) ([]*models.CharacterItem, error)
 }
+
+type SkillRepo interface {
+	GetSkills(ctx context.Context, characterID uint) ([]*models.Skill, error)
+	GetMacros(ctx context.Context, characterID uint) ([]*models.SkillMacro, error)
+	GetCooldowns(ctx context.Context, characterID uint) ([]*models.SkillCooldown, error)
+	Save(ctx context.Context, characterID uint, skills []*models.Skill, macros []*models.SkillMacro, cooldowns []*models.SkillCooldown) error
+}
+
+type BuddyRepo interface {
+	GetBuddies(ctx context.Context, characterID uint) ([]*models.Buddy, error)
+	GetBuddy(ctx context.Context, characterID, buddyID uint) (*models.Buddy, error)
+	CountListed(ctx context.Context, characterID uint) (int64, error)
+	GetFriendsOf(ctx context.Context, buddyID uint) ([]uint, error)
+	Save(ctx context.Context, buddies ...*models.Buddy) error
+	Delete(ctx context.Context, characterID, buddyID uint) error
+}
+
+type GuildRepo interface {
+	FindByID(ctx context.Context, id uint) (*models.Guild, error)
+	NameExists(ctx context.Context, worldID byte, name string) (bool, error)
+	Create(ctx context.Context, guild *models.Guild) error
+	Update(ctx context.Context, guild *models.Guild) error
+	Delete(ctx context.Context, id uint) error
+	GetMembers(ctx context.Context, guildID uint) ([]*models.Character, error)
+	SetMember(ctx context.Context, characterID uint, guildID *uint, grade byte) error
+}
+
+type DropRepo interface {
+	GetAll(ctx context.Context) ([]*models.DropEntry, error)
+}
@@ -91,8 +91,8 @@ func (b *Builder) If(cond bool, fn func(*Builder)) *Builder {
 }
 
 // Build returns the completed packet.
+// The connection adds the length header on write, so the opcode is left in place.
 func (b *Builder) Build() Packet {
-	b.p.SetLength()
 	return b.p
 }
 
@@ -28,6 +28,23 @@ func GetItemTypeByItemID(itemID int32) ItemType {
 	}
 }
 
+// GetInventoryTypeByItemID returns the inventory tab an item is stored in, or 0 if unknown
+func GetInventoryTypeByItemID(itemID int32) models.InventoryType {
+	switch itemID / 1_000_000 {
+	case 1:
+		return models.InvEquip
+	case 2:
+		return models.InvConsume
+	case 3:
+		return models.InvInstall
+	case 4:
+		return models.InvEtc
+	case 5:
+		return models.InvCash
+	}
+	return 0
+}
+
 type invBuckets struct {
 	equipped []*models.CharacterItem // InvEquipped (slot < 0)
 	equip    []*models.CharacterItem // InvEquip
@@ -115,3 +132,67 @@ func NewEquipFromItemInfo(info *item.ItemInfo, invType models.InventoryType, slo
 		RUC:      getStatByte(item.KeyTUC),
 	}
 }
+
+// GetBodyPartsByItemID returns the body parts an equip can be worn on
+func GetBodyPartsByItemID(itemID int32) []item.BodyPart {
+	switch category := itemID / 10_000; {
+	case category == 100:
+		return []item.BodyPart{item.BodyPartCap}
+	case category == 101:
+		return []item.BodyPart{item.BodyPartAccessory1}
+	case category == 102:
+		return []item.BodyPart{item.BodyPartAccessory2}
+	case category == 103:
+		return []item.BodyPart{item.BodyPartEarring}
+	case category == 104, category == 105:
+		return []item.BodyPart{item.BodyPartClothes}
+	case category == 106:
+		return []item.BodyPart{item.BodyPartPants}
+	case category == 107:
+		return []item.BodyPart{item.BodyPartShoes}
+	case category == 108:
+		return []item.BodyPart{item.BodyPartGloves}
+	case category == 109:
+		return []item.BodyPart{item.BodyPartShield}
+	case category == 110:
+		return []item.BodyPart{item.BodyPartCape}
+	case category == 111:
+		return []item.BodyPart{item.BodyPartRing1, item.BodyPartRing2, item.BodyPartRing3, item.BodyPartRing4}
+	case category == 112:
+		return []item.BodyPart{item.BodyPartPendant}
+	case category == 113:
+		return []item.BodyPart{item.BodyPartBelt}
+	case category == 114:
+		return []item.BodyPart{item.BodyPartMedal}
+	case category >= 130 && category < 170:
+		return []item.BodyPart{item.BodyPartWeapon}
+	case category == 190:
+		return []item.BodyPart{item.BodyPartMount}
+	case category == 191:
+		return []item.BodyPart{item.BodyPartSaddle}
+	}
+	return nil
+}
+
+// IsOverall checks if an equip covers both the top and pants slots
+func IsOverall(itemID int32) bool {
+	return itemID/10_000 == 105
+}
+
+// IsValidEquipSlot checks if an equip can be worn in the given (negative) equipped slot.
+// Cash equips are worn 100 slots further down.
+func IsValidEquipSlot(itemID int32, slot int16, cash bool) bool {
+	if slot >= 0 {
+		return false
+	}
+	bodyPart := item.BodyPart(-slot)
+	if cash {
+		bodyPart = item.BodyPart(-(slot + 100))
+	}
+	for _, bp := range GetBodyPartsByItemID(itemID) {
+		if bp == bodyPart {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+-- NPC Script: Heracle (2010007)
+-- Guild headquarters: create, expand, or disband a guild
+
+local isMaster = player.isGuildMaster()
+
+local selection = npc.askMenu("What would you like to do?\r\n" ..
+    "#L0#Create a guild#l\r\n" ..
+    "#L1#Increase guild capacity#l\r\n" ..
+    "#L2#Disband guild#l")
+
+if selection == 0 then
+    if player.getGuildId() ~= 0 then
+        npc.say("You're already in a guild.")
+    elseif npc.askYesNo("Creating a guild costs #b1,500,000 mesos#k. Would you like to create one?") then
+        if not player.createGuild() then
+            npc.say("You don't have enough mesos to create a guild.")
+        end
+    end
+elseif selection == 1 then
+    local cost = player.getGuildCapacityCost()
+    if not isMaster then
+        npc.say("Only the guild master can increase the guild's capacity.")
+    elseif cost == 0 then
+        npc.say("Your guild can't grow any larger.")
+    elseif npc.askYesNo("Adding room for 5 more members costs #b" .. cost .. " mesos#k. Continue?") then
+        if not player.increaseGuildCapacity() then
+            npc.say("You don't have enough mesos.")
+        end
+    end
+elseif selection == 2 then
+    if not isMaster then
+        npc.say("Only the guild master can disband the guild.")
+    elseif npc.askYesNo("Are you sure you want to disband your guild? This can't be undone.") then
+        if player.disbandGuild() then
+            npc.say("Your guild has been disbanded.")
+        else
+            npc.say("Your guild couldn't be disbanded. Please try again later.")
+        end
+    end
+end
+
+npc.dispose()
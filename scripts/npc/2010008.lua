@@ -0,0 +1,12 @@
+-- NPC Script: Lea (2010008)
+-- Guild emblem registration
+
+if not player.isGuildMaster() then
+    npc.say("Only the guild master can register a guild emblem.")
+elseif npc.askYesNo("Registering a guild emblem costs #b5,000,000 mesos#k. Would you like to continue?") then
+    if not player.changeGuildMark() then
+        npc.say("You don't have enough mesos to register an emblem.")
+    end
+end
+
+npc.dispose()